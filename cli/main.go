@@ -1,44 +1,382 @@
 package main
 
 import (
+	"NintendoChannel/config"
+	"NintendoChannel/constants"
 	"NintendoChannel/csdata"
 	"NintendoChannel/dllist"
 	"NintendoChannel/thumbnail"
+	"NintendoChannel/tpl"
+	"bytes"
+	"errors"
+	"flag"
 	"fmt"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"io/fs"
 	"os"
-	"strconv"
+	"path/filepath"
+	"strings"
 )
 
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+}
+
+var commands = []command{
+	{"dllist", "Generate dllist.bin and any new or changed game info files", runDownloadList("dllist", false)},
+	{"info", "Generate dllist.bin and regenerate every game info file", runDownloadList("info", true)},
+	{"thumbnail", "Generate thumbnail.bin for every region and language", runThumbnail},
+	{"csdata", "Generate csdata.bn for every region and language, or inspect or decrypt one", runCSData},
+	{"verify", "Verify generated dllist.bin files", runVerify},
+	{"tpl", "Convert between PNG or JPEG images and TPL textures", runTPL},
+}
+
 func main() {
 	fmt.Println("WiiLink Nintendo Channel File Generator")
 	fmt.Println()
 
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: ", os.Args[0], " <operation>")
-		fmt.Println("Available operations:")
-		fmt.Println("1 - DLList and game info")
-		fmt.Println("2 - DLList and game info (force)")
-		fmt.Println("3 - Thumbnails")
-		fmt.Println("4 - CSData")
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		usage()
 		return
 	}
 
-	selection, err := strconv.Atoi(os.Args[1])
-	if err != nil {
-		fmt.Println("Invalid input. Please provide a valid numeric operation.")
+	for _, cmd := range commands {
+		if cmd.name != os.Args[1] {
+			continue
+		}
+
+		err := cmd.run(os.Args[2:])
+		if err == flag.ErrHelp {
+			return
+		} else if err != nil {
+			fmt.Printf("%s: %v\n", cmd.name, err)
+			os.Exit(1)
+		}
+
 		return
 	}
 
-	switch selection {
-	case 1:
-		dllist.MakeDownloadList(false)
-	case 2:
-		dllist.MakeDownloadList(true)
-	case 3:
-		thumbnail.WriteThumbnail()
-	case 4:
-		csdata.CreateCSData()
-	default:
-		fmt.Println("\nInvalid Selection")
+	fmt.Printf("Unknown command %q\n\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Printf("Usage: %s <command> [flags]\n", os.Args[0])
+	fmt.Println("Available commands:")
+	for _, cmd := range commands {
+		fmt.Printf("  %-10s %s\n", cmd.name, cmd.description)
+	}
+
+	fmt.Println()
+	fmt.Printf("Run %s <command> --help for the flags of a command.\n", os.Args[0])
+}
+
+const defaultConfigPath = "config.json"
+
+func newFlagSet(name string) *flag.FlagSet {
+	set := flag.NewFlagSet(name, flag.ContinueOnError)
+	set.Usage = func() {
+		fmt.Fprintf(set.Output(), "Usage: %s %s [flags]\n", os.Args[0], name)
+		set.PrintDefaults()
+	}
+
+	return set
+}
+
+// configFlags registers --config and --out on a flag set and loads the configuration they point to.
+// Without --config, a missing config.json leaves the configuration to the defaults and NC_* environment variables.
+func configFlags(set *flag.FlagSet, outputUsage string) func() (*config.Config, error) {
+	configPath := set.String("config", defaultConfigPath, "path to the configuration file")
+	outputDir := set.String("out", "", outputUsage+" (default output_dir from the configuration)")
+
+	return func() (*config.Config, error) {
+		path := *configPath
+		if !flagSet(set, "config") {
+			if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+				fmt.Printf("%s does not exist, using the defaults and environment variables\n", path)
+				path = ""
+			}
+		}
+
+		cfg, err := config.Load(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if *outputDir != "" {
+			cfg.OutputDir = *outputDir
+		}
+
+		return cfg, nil
+	}
+}
+
+// flagSet reports whether a flag was passed on the command line.
+func flagSet(set *flag.FlagSet, name string) bool {
+	found := false
+	set.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			found = true
+		}
+	})
+
+	return found
+}
+
+// regionFlags registers --region and --language on a flag set and resolves them into entries of constants.Regions.
+func regionFlags(set *flag.FlagSet) func() ([]constants.RegionMeta, error) {
+	regionList := set.String("region", "", "comma separated regions (JP, GB, US), default all")
+	languageList := set.String("language", "", "comma separated languages (ja, en, de, fr, es, it, nl), default all")
+
+	return func() ([]constants.RegionMeta, error) {
+		var regions []constants.Region
+		for _, name := range splitList(*regionList) {
+			region, err := constants.ParseRegion(name)
+			if err != nil {
+				return nil, err
+			}
+
+			regions = append(regions, region)
+		}
+
+		var languages []constants.Language
+		for _, name := range splitList(*languageList) {
+			language, err := constants.ParseLanguage(name)
+			if err != nil {
+				return nil, err
+			}
+
+			languages = append(languages, language)
+		}
+
+		filtered := constants.FilterRegions(regions, languages)
+		if len(filtered) == 0 {
+			return nil, fmt.Errorf("no region supports the selected languages")
+		}
+
+		return filtered, nil
+	}
+}
+
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func runDownloadList(name string, force bool) func(args []string) error {
+	return func(args []string) error {
+		set := newFlagSet(name)
+		getConfig := configFlags(set, "directory to write lists/ and infos/ to")
+		getRegions := regionFlags(set)
+		overwrite := set.Bool("force", force, "regenerate every game info file, even those whose inputs did not change")
+		if err := set.Parse(args); err != nil {
+			return err
+		}
+
+		cfg, err := getConfig()
+		if err != nil {
+			return err
+		}
+
+		regions, err := getRegions()
+		if err != nil {
+			return err
+		}
+
+		return dllist.MakeDownloadList(cfg, regions, *overwrite)
+	}
+}
+
+func runThumbnail(args []string) error {
+	set := newFlagSet("thumbnail")
+	getConfig := configFlags(set, "directory to write thumbnails/ to")
+	getRegions := regionFlags(set)
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := getConfig()
+	if err != nil {
+		return err
+	}
+
+	regions, err := getRegions()
+	if err != nil {
+		return err
+	}
+
+	return thumbnail.WriteThumbnails(cfg, regions)
+}
+
+func runCSData(args []string) error {
+	if len(args) != 0 {
+		switch args[0] {
+		case "inspect":
+			return runCSDataInspect(args[1:])
+		case "decrypt":
+			return runCSDataDecrypt(args[1:])
+		}
+	}
+
+	set := newFlagSet("csdata")
+	getConfig := configFlags(set, "directory to write dir/ to")
+	getRegions := regionFlags(set)
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := getConfig()
+	if err != nil {
+		return err
+	}
+
+	regions, err := getRegions()
+	if err != nil {
+		return err
+	}
+
+	return csdata.CreateCSData(cfg, regions)
+}
+
+func runCSDataInspect(args []string) error {
+	set := newFlagSet("csdata inspect")
+	input := set.String("in", "", "csdata.bn to inspect")
+	publicKey := set.String("key", "", "PEM key or wc24pubk.mod to verify the signature with")
+	exportDir := set.String("export", "", "directory to export the banner textures to")
+	width := set.Int("width", csdata.BannerWidth, "width of the banners, to export them as PNGs")
+	height := set.Int("height", csdata.BannerHeight, "height of the banners, to export them as PNGs")
+	formatName := set.String("format", "RGB5A3", "texture format of the banners (I8, RGB565, RGB5A3, CMPR)")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	if *input == "" {
+		set.Usage()
+		return flag.ErrHelp
+	}
+
+	format, err := tpl.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	return csdata.Inspect(*input, csdata.InspectOptions{
+		PublicKeyPath: *publicKey,
+		ExportDir:     *exportDir,
+		Width:         *width,
+		Height:        *height,
+		Format:        format,
+	})
+}
+
+func runCSDataDecrypt(args []string) error {
+	set := newFlagSet("csdata decrypt")
+	input := set.String("in", "", "csdata.bn to decrypt")
+	output := set.String("out", "", "file to write the decrypted and decompressed contents to")
+	if err := set.Parse(args); err != nil {
+		return err
 	}
+
+	if *input == "" || *output == "" {
+		set.Usage()
+		return flag.ErrHelp
+	}
+
+	return csdata.DecryptFile(*input, *output)
+}
+
+func runVerify(args []string) error {
+	set := newFlagSet("verify")
+	getConfig := configFlags(set, "directory the lists/ and thumbnails/ to verify were written to")
+	getRegions := regionFlags(set)
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := getConfig()
+	if err != nil {
+		return err
+	}
+
+	regions, err := getRegions()
+	if err != nil {
+		return err
+	}
+
+	if !dllist.VerifyDownloadLists(regions, cfg.OutputDir) {
+		return fmt.Errorf("one or more lists failed verification")
+	}
+
+	return nil
+}
+
+// runTPL encodes an image to a TPL file, or decodes a TPL file to a PNG to preview it.
+func runTPL(args []string) error {
+	set := newFlagSet("tpl")
+	input := set.String("in", "", "image or TPL file to convert")
+	output := set.String("out", "", "TPL file to encode to, or PNG file to decode to")
+	formatName := set.String("format", "RGB5A3", "texture format when encoding (I8, RGB565, RGB5A3, CMPR)")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+
+	if *input == "" || *output == "" {
+		set.Usage()
+		return flag.ErrHelp
+	}
+
+	contents, err := os.ReadFile(*input)
+	if err != nil {
+		return err
+	}
+
+	var converted []byte
+	if strings.EqualFold(filepath.Ext(*input), ".tpl") {
+		texture, err := tpl.DecodeFile(contents)
+		if err != nil {
+			return err
+		}
+
+		img, err := texture.Image()
+		if err != nil {
+			return err
+		}
+
+		buffer := new(bytes.Buffer)
+		err = png.Encode(buffer, img)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Decoded %dx%d %s texture\n", texture.Width, texture.Height, texture.Format)
+		converted = buffer.Bytes()
+	} else {
+		format, err := tpl.ParseFormat(*formatName)
+		if err != nil {
+			return err
+		}
+
+		img, _, err := image.Decode(bytes.NewReader(contents))
+		if err != nil {
+			return err
+		}
+
+		converted, err = tpl.EncodeFile(img, format)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(*output, converted, 0666)
 }
@@ -0,0 +1,256 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Config holds the settings shared by every generator.
+type Config struct {
+	Database  Database `json:"database"`
+	OutputDir string   `json:"output_dir"`
+	GameTDB   GameTDB  `json:"gametdb"`
+	// ListID is the ID written to dllist.bin and csdata.bn.
+	ListID uint32 `json:"list_id"`
+	// InfoListID is the DLList ID written to game info files.
+	InfoListID uint32     `json:"info_list_id"`
+	CSData     CSData     `json:"csdata"`
+	NewTitles  NewTitles  `json:"new_titles"`
+	CoverArt   CoverArt   `json:"cover_art"`
+	Thumbnails Thumbnails `json:"thumbnails"`
+	// Locales maps a language code such as "nl", or a region and language such as "US/es",
+	// to the GameTDB locales its titles are taken from, in order of preference.
+	// A region and language takes precedence over the language alone.
+	Locales map[string][]string `json:"locales"`
+	// TitleOverridesPath is a JSON file replacing the generated title fields of chosen games.
+	TitleOverridesPath string `json:"title_overrides_path"`
+	// DescriptorFonts are the fonts rating descriptors are drawn with, in order of preference.
+	// A character missing from every font is drawn with Go Regular.
+	DescriptorFonts []string `json:"descriptor_fonts"`
+}
+
+// Database contains the connection details of the data store.
+type Database struct {
+	// Driver is one of "mysql", "postgres" or "json".
+	Driver string `json:"driver"`
+	// Path is the file read by the json driver.
+	Path     string `json:"path"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+	// MaxOpenConns and MaxIdleConns limit the connection pool shared by all workers.
+	MaxOpenConns int `json:"max_open_conns"`
+	MaxIdleConns int `json:"max_idle_conns"`
+}
+
+// GameTDB contains where the GameTDB databases are loaded from.
+type GameTDB struct {
+	// WiiURL, DSURL and ThreeDSURL are either URLs to zip archives or paths to local .xml or .zip files.
+	WiiURL     string `json:"wii_url"`
+	DSURL      string `json:"ds_url"`
+	ThreeDSURL string `json:"3ds_url"`
+	// CacheDir keeps downloaded archives, which are only downloaded again once they change.
+	CacheDir string `json:"cache_dir"`
+	// SnapshotDir keeps a copy of every database version that has been loaded.
+	SnapshotDir string `json:"snapshot_dir"`
+	// Pinned maps a database name (wiitdb, dstdb or 3dstdb) to the version to load from SnapshotDir.
+	Pinned map[string]string `json:"pinned"`
+}
+
+// NewTitles controls which titles are listed as new arrivals.
+type NewTitles struct {
+	// WindowDays is how many days after its release or first appearance in GameTDB a title is new.
+	WindowDays int `json:"window_days"`
+	// Max is the most titles listed as new in each list. A negative Max lists every new title.
+	Max int `json:"max"`
+	// FirstSeenPath stores when each title first appeared in GameTDB.
+	FirstSeenPath string `json:"first_seen_path"`
+}
+
+// CoverArt controls where the covers in info files are taken from.
+type CoverArt struct {
+	// LocalDir holds covers that take priority over GameTDB, as <console>/<region>/<game ID>.png
+	// or <console>/<game ID>.png. Covers may also be .jpg files.
+	LocalDir string `json:"local_dir"`
+	// CacheDir keeps every cover downloaded from GameTDB, so it is only downloaded once.
+	// It also records the covers GameTDB does not have, which are only requested again after a week.
+	// Without it, covers are kept in memory until the run ends.
+	CacheDir string `json:"cache_dir"`
+	// Retries is how many more times a failed download is attempted, waiting twice as long each time.
+	Retries        int `json:"retries"`
+	TimeoutSeconds int `json:"timeout_seconds"`
+}
+
+// Thumbnails controls where the video thumbnails in thumbnail.bin are taken from.
+type Thumbnails struct {
+	// ImageDir holds the image of every video, as <region>/<language>/<video ID>.png or <video ID>.png.
+	// Images may also be .jpg files, or .img files that are already encoded thumbnails.
+	ImageDir string `json:"image_dir"`
+	// CacheDir keeps every encoded thumbnail, so an image is only encoded again once it or the settings change.
+	CacheDir string `json:"cache_dir"`
+	// Width and Height are the dimensions images are scaled to, keeping their aspect ratio.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// Quality is the JPEG quality thumbnails are encoded with, from 1 to 100.
+	Quality int `json:"quality"`
+}
+
+// CSData contains the paths to the keys used to sign csdata.bn.
+type CSData struct {
+	RSAKeyPath string `json:"rsa_key_path"`
+	// BannerManifestPath is a JSON file listing the text and images of the banners.
+	BannerManifestPath string `json:"banner_manifest_path"`
+}
+
+// Default returns the configuration used for any value not set by the file or environment.
+func Default() *Config {
+	return &Config{
+		Database: Database{
+			Driver:       "mysql",
+			Host:         "127.0.0.1",
+			Port:         3306,
+			User:         "rc24",
+			Name:         "rc24_nc",
+			MaxOpenConns: 10,
+			MaxIdleConns: 5,
+		},
+		OutputDir: ".",
+		GameTDB: GameTDB{
+			WiiURL:     "https://www.gametdb.com/wiitdb.zip",
+			DSURL:      "https://www.gametdb.com/dstdb.zip",
+			ThreeDSURL: "https://www.gametdb.com/3dstdb.zip",
+		},
+		ListID:     434968891,
+		InfoListID: 1254762001,
+		CSData: CSData{
+			RSAKeyPath:         "nc.pem",
+			BannerManifestPath: "banners/banners.json",
+		},
+		NewTitles: NewTitles{
+			WindowDays:    30,
+			Max:           50,
+			FirstSeenPath: "first_seen.json",
+		},
+		CoverArt: CoverArt{
+			CacheDir:       "cache/covers",
+			Retries:        3,
+			TimeoutSeconds: 30,
+		},
+		Thumbnails: Thumbnails{
+			ImageDir: "movie",
+			CacheDir: "cache/thumbnails",
+			Width:    160,
+			Height:   120,
+			Quality:  90,
+		},
+		Locales: map[string][]string{
+			"ja": {"JA", "EN"},
+			"en": {"EN"},
+			"de": {"DE", "EN"},
+			"fr": {"FR", "EN"},
+			"es": {"ES", "EN"},
+			"it": {"IT", "EN"},
+			"nl": {"NL", "EN"},
+		},
+		TitleOverridesPath: "title_overrides.json",
+		DescriptorFonts:    []string{"FOT-RodinNTLGPro-DB.otf"},
+	}
+}
+
+// Load reads the JSON configuration at path on top of Default, then applies environment overrides.
+// An empty path configures everything from Default and the environment.
+func Load(path string) (*Config, error) {
+	config := Default()
+
+	if path != "" {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		err = json.Unmarshal(contents, config)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	err := config.applyEnvironment()
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// applyEnvironment overrides values with any NC_* environment variables that are set.
+func (c *Config) applyEnvironment() error {
+	stringValues := map[string]*string{
+		"NC_DB_DRIVER":         &c.Database.Driver,
+		"NC_DB_PATH":           &c.Database.Path,
+		"NC_DB_HOST":           &c.Database.Host,
+		"NC_DB_USER":           &c.Database.User,
+		"NC_DB_PASSWORD":       &c.Database.Password,
+		"NC_DB_NAME":           &c.Database.Name,
+		"NC_OUTPUT_DIR":        &c.OutputDir,
+		"NC_GAMETDB_WII":       &c.GameTDB.WiiURL,
+		"NC_GAMETDB_DS":        &c.GameTDB.DSURL,
+		"NC_GAMETDB_3DS":       &c.GameTDB.ThreeDSURL,
+		"NC_GAMETDB_CACHE":     &c.GameTDB.CacheDir,
+		"NC_GAMETDB_SNAPSHOTS": &c.GameTDB.SnapshotDir,
+		"NC_CSDATA_RSA_KEY":    &c.CSData.RSAKeyPath,
+		"NC_CSDATA_BANNERS":    &c.CSData.BannerManifestPath,
+		"NC_COVER_ART_DIR":     &c.CoverArt.LocalDir,
+		"NC_COVER_ART_CACHE":   &c.CoverArt.CacheDir,
+		"NC_THUMBNAIL_DIR":     &c.Thumbnails.ImageDir,
+		"NC_THUMBNAIL_CACHE":   &c.Thumbnails.CacheDir,
+	}
+
+	for name, value := range stringValues {
+		if env, ok := os.LookupEnv(name); ok {
+			*value = env
+		}
+	}
+
+	intValues := map[string]*int{
+		"NC_DB_PORT":                &c.Database.Port,
+		"NC_DB_MAX_OPEN_CONNS":      &c.Database.MaxOpenConns,
+		"NC_DB_MAX_IDLE_CONNS":      &c.Database.MaxIdleConns,
+		"NC_NEW_TITLES_WINDOW_DAYS": &c.NewTitles.WindowDays,
+		"NC_NEW_TITLES_MAX":         &c.NewTitles.Max,
+		"NC_COVER_ART_RETRIES":      &c.CoverArt.Retries,
+		"NC_COVER_ART_TIMEOUT":      &c.CoverArt.TimeoutSeconds,
+	}
+
+	for name, value := range intValues {
+		if env, ok := os.LookupEnv(name); ok {
+			number, err := strconv.Atoi(env)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			*value = number
+		}
+	}
+
+	ids := map[string]*uint32{
+		"NC_LIST_ID":      &c.ListID,
+		"NC_INFO_LIST_ID": &c.InfoListID,
+	}
+
+	for name, value := range ids {
+		if env, ok := os.LookupEnv(name); ok {
+			id, err := strconv.ParseUint(env, 10, 32)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			*value = uint32(id)
+		}
+	}
+
+	return nil
+}
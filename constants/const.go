@@ -1,5 +1,11 @@
 package constants
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // RatingGroup is the rating organization for a specific region.
 type RatingGroup uint8
 
@@ -21,6 +27,18 @@ type RatingData struct {
 	Age  uint8
 }
 
+const (
+	// FirstRatingID is the rating ID of the first entry of RatingsData of a RatingGroup.
+	FirstRatingID uint8 = 8
+	// RatingPendingID is the ESRB "RP" mark for titles that have not been rated yet.
+	RatingPendingID uint8 = 15
+	// NoRatingID is a title without a rating, shown without any rating image.
+	NoRatingID uint8 = 255
+	// UnratedRatingID is shown for titles GameTDB has no rating for: E for ESRB, 7 for PEGI and B for CERO,
+	// as our lists always have.
+	UnratedRatingID uint8 = 9
+)
+
 var RatingsData = map[RatingGroup][]RatingData{
 	CERO: {
 		{Name: [11]uint16{'A'}, Age: 0},
@@ -66,10 +84,58 @@ const (
 	Dutch
 )
 
+var regionNames = map[Region]string{
+	Japan: "JP",
+	PAL:   "GB",
+	NTSC:  "US",
+}
+
+var languageNames = map[Language]string{
+	Japanese: "ja",
+	English:  "en",
+	German:   "de",
+	French:   "fr",
+	Spanish:  "es",
+	Italian:  "it",
+	Dutch:    "nl",
+}
+
+func (r Region) String() string {
+	return regionNames[r]
+}
+
+func (l Language) String() string {
+	return languageNames[l]
+}
+
+// ParseRegion returns the Region for a name such as "US", or its numeric ID.
+func ParseRegion(name string) (Region, error) {
+	for region, regionName := range regionNames {
+		if strings.EqualFold(name, regionName) || name == strconv.Itoa(int(region)) {
+			return region, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown region %q", name)
+}
+
+// ParseLanguage returns the Language for a name such as "en", or its numeric ID.
+func ParseLanguage(name string) (Language, error) {
+	for language, languageName := range languageNames {
+		if strings.EqualFold(name, languageName) || name == strconv.Itoa(int(language)) {
+			return language, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown language %q", name)
+}
+
 type RegionMeta struct {
 	Region      Region
 	Languages   []Language
 	RatingGroup RatingGroup
+	// CountryCode is the Wii country code of the country the region is served to.
+	CountryCode uint32
 }
 
 var Regions = []RegionMeta{
@@ -77,19 +143,69 @@ var Regions = []RegionMeta{
 		Region:      Japan,
 		Languages:   []Language{Japanese},
 		RatingGroup: CERO,
+		CountryCode: 1,
 	},
 	{
 		Region:      NTSC,
 		Languages:   []Language{English, French, Spanish},
 		RatingGroup: ESRB,
+		CountryCode: 49,
 	},
 	{
 		Region:      PAL,
 		Languages:   []Language{English, German, French, Spanish, Italian, Dutch},
 		RatingGroup: PEGI,
+		CountryCode: 110,
 	},
 }
 
+// FilterRegions returns the entries of Regions matching the given regions and languages.
+// An empty filter matches everything.
+func FilterRegions(regions []Region, languages []Language) []RegionMeta {
+	var filtered []RegionMeta
+	for _, meta := range Regions {
+		if len(regions) != 0 && !containsRegion(regions, meta.Region) {
+			continue
+		}
+
+		var metaLanguages []Language
+		for _, language := range meta.Languages {
+			if len(languages) == 0 || containsLanguage(languages, language) {
+				metaLanguages = append(metaLanguages, language)
+			}
+		}
+
+		if len(metaLanguages) == 0 {
+			continue
+		}
+
+		meta.Languages = metaLanguages
+		filtered = append(filtered, meta)
+	}
+
+	return filtered
+}
+
+func containsRegion(regions []Region, region Region) bool {
+	for _, r := range regions {
+		if r == region {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsLanguage(languages []Language, language Language) bool {
+	for _, l := range languages {
+		if l == language {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ConsoleModels is the type of consoles the Nintendo Channel games has.
 type ConsoleModels [3]byte
 
@@ -231,47 +347,3 @@ const (
 	Gold
 	Platinum
 )
-
-func GetPopularVideoQueryString(language Language) string {
-	switch language {
-	case Japanese:
-		return `SELECT id, name_japanese, length, video_type FROM videos ORDER BY RAND() DESC`
-	case English:
-		return `SELECT id, name_english, length, video_type FROM videos ORDER BY RAND() DESC`
-	case German:
-		return `SELECT id, name_german, length, video_type FROM videos ORDER BY RAND() DESC`
-	case French:
-		return `SELECT id, name_french, length, video_type FROM videos ORDER BY RAND() DESC`
-	case Spanish:
-		return `SELECT id, name_spanish, length, video_type FROM videos ORDER BY RAND() DESC`
-	case Italian:
-		return `SELECT id, name_italian, length, video_type FROM videos ORDER BY RAND() DESC`
-	case Dutch:
-		return `SELECT id, name_dutch, length, video_type FROM videos ORDER BY RAND() DESC`
-	default:
-		// Will never reach here
-		return ""
-	}
-}
-
-func GetVideoQueryString(language Language) string {
-	switch language {
-	case Japanese:
-		return `SELECT id, name_japanese, length, video_type FROM videos ORDER BY date_added DESC`
-	case English:
-		return `SELECT id, name_english, length, video_type FROM videos ORDER BY date_added DESC`
-	case German:
-		return `SELECT id, name_german, length, video_type FROM videos ORDER BY date_added DESC`
-	case French:
-		return `SELECT id, name_french, length, video_type FROM videos ORDER BY date_added DESC`
-	case Spanish:
-		return `SELECT id, name_spanish, length, video_type FROM videos ORDER BY date_added DESC`
-	case Italian:
-		return `SELECT id, name_italian, length, video_type FROM videos ORDER BY date_added DESC`
-	case Dutch:
-		return `SELECT id, name_dutch, length, video_type FROM videos ORDER BY date_added DESC`
-	default:
-		// Will never reach here
-		return ""
-	}
-}
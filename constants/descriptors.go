@@ -0,0 +1,159 @@
+package constants
+
+// Descriptor is a content descriptor from a fixed set of a rating board.
+// Descriptors are drawn as text only, until we have the official PEGI and CERO icons to draw with them.
+type Descriptor struct {
+	// Key identifies the descriptor.
+	Key string
+	// Names are the descriptor in every language of the lists of the board.
+	Names map[Language]string
+	// Aliases are the other ways GameTDB writes the descriptor, after normalization.
+	Aliases []string
+}
+
+// Descriptors are the content descriptors of the rating boards that have a fixed set of them.
+// ESRB descriptors are free text and are not listed.
+var Descriptors = map[RatingGroup][]Descriptor{
+	PEGI: {
+		{
+			Key: "violence",
+			Names: map[Language]string{
+				English: "Violence",
+				German:  "Gewalt",
+				French:  "Violence",
+				Spanish: "Violencia",
+				Italian: "Violenza",
+				Dutch:   "Geweld",
+			},
+		},
+		{
+			Key: "language",
+			Names: map[Language]string{
+				English: "Bad Language",
+				German:  "Vulgäre Sprache",
+				French:  "Langage grossier",
+				Spanish: "Lenguaje soez",
+				Italian: "Linguaggio scurrile",
+				Dutch:   "Grof taalgebruik",
+			},
+			Aliases: []string{"bad language", "strong language", "crude language"},
+		},
+		{
+			Key: "fear",
+			Names: map[Language]string{
+				English: "Fear",
+				German:  "Angst",
+				French:  "Peur",
+				Spanish: "Miedo",
+				Italian: "Paura",
+				Dutch:   "Angst",
+			},
+			Aliases: []string{"horror"},
+		},
+		{
+			Key: "sex",
+			Names: map[Language]string{
+				English: "Sex",
+				German:  "Sex",
+				French:  "Sexe",
+				Spanish: "Sexo",
+				Italian: "Sesso",
+				Dutch:   "Seks",
+			},
+			Aliases: []string{"sexual content", "nudity"},
+		},
+		{
+			Key: "drugs",
+			Names: map[Language]string{
+				English: "Drugs",
+				German:  "Drogen",
+				French:  "Drogue",
+				Spanish: "Drogas",
+				Italian: "Droga",
+				Dutch:   "Drugs",
+			},
+			Aliases: []string{"drug"},
+		},
+		{
+			Key: "discrimination",
+			Names: map[Language]string{
+				English: "Discrimination",
+				German:  "Diskriminierung",
+				French:  "Discrimination",
+				Spanish: "Discriminación",
+				Italian: "Discriminazione",
+				Dutch:   "Discriminatie",
+			},
+		},
+		{
+			Key: "gambling",
+			Names: map[Language]string{
+				English: "Gambling",
+				German:  "Glücksspiel",
+				French:  "Jeux de hasard",
+				Spanish: "Juego",
+				Italian: "Gioco d'azzardo",
+				Dutch:   "Gokken",
+			},
+		},
+		{
+			Key: "online",
+			Names: map[Language]string{
+				English: "Online Gameplay",
+				German:  "Online-Spiel",
+				French:  "Jeu en ligne",
+				Spanish: "Juego en línea",
+				Italian: "Gioco online",
+				Dutch:   "Online spelen",
+			},
+			Aliases: []string{"online gameplay", "online play"},
+		},
+	},
+	CERO: {
+		{
+			Key:     "love",
+			Names:   map[Language]string{Japanese: "恋愛", English: "Love"},
+			Aliases: []string{"romance", "恋愛"},
+		},
+		{
+			Key:     "sexual",
+			Names:   map[Language]string{Japanese: "セクシャル", English: "Sexual Content"},
+			Aliases: []string{"sex", "sexual content", "セクシャル"},
+		},
+		{
+			Key:     "violence",
+			Names:   map[Language]string{Japanese: "暴力", English: "Violence"},
+			Aliases: []string{"暴力"},
+		},
+		{
+			Key:     "horror",
+			Names:   map[Language]string{Japanese: "恐怖", English: "Horror"},
+			Aliases: []string{"fear", "恐怖"},
+		},
+		{
+			Key:     "drinking_smoking",
+			Names:   map[Language]string{Japanese: "飲酒・喫煙", English: "Drinking/Smoking"},
+			Aliases: []string{"drinking smoking", "drinking", "smoking", "alcohol", "tobacco", "飲酒 喫煙"},
+		},
+		{
+			Key:     "gambling",
+			Names:   map[Language]string{Japanese: "ギャンブル", English: "Gambling"},
+			Aliases: []string{"ギャンブル"},
+		},
+		{
+			Key:     "crime",
+			Names:   map[Language]string{Japanese: "犯罪", English: "Crime"},
+			Aliases: []string{"犯罪"},
+		},
+		{
+			Key:     "drugs",
+			Names:   map[Language]string{Japanese: "麻薬等薬物", English: "Drugs"},
+			Aliases: []string{"drug", "麻薬等薬物"},
+		},
+		{
+			Key:     "language",
+			Names:   map[Language]string{Japanese: "言葉・その他", English: "Language"},
+			Aliases: []string{"language other", "other", "言葉 その他"},
+		},
+	},
+}
@@ -0,0 +1,13 @@
+package constants
+
+import "testing"
+
+func TestDescriptorNames(t *testing.T) {
+	for board, descriptors := range Descriptors {
+		for _, descriptor := range descriptors {
+			if descriptor.Names[English] == "" {
+				t.Errorf("rating group %d: descriptor %s has no English name to fall back to", board, descriptor.Key)
+			}
+		}
+	}
+}
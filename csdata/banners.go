@@ -0,0 +1,198 @@
+package csdata
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/tpl"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxBanners is how many banners csdata.bn has room for.
+const MaxBanners = 3
+
+// BannerWidth and BannerHeight are the size we expect the banners the channel shows to have.
+// They have not been confirmed against a csdata.bn from Nintendo, so banners of another size are only warned about.
+// A banner's PictureSize is Width*Height*2 for RGB5A3, which csdata inspect prints.
+const (
+	BannerWidth  = 128
+	BannerHeight = 96
+)
+
+// BannerManifest lists the banners shown in the Nintendo Channel.
+//
+// Text and images are looked up by "<region>/<language>" such as "US/en", then by language such as "en",
+// then by "default". Image paths are relative to the manifest. Images are either TPL files or
+// PNG and JPEG images, which are encoded to Format.
+type BannerManifest struct {
+	// Width and Height are the dimensions banner images are expected to have, BannerWidth and BannerHeight by default.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// Format is the texture format of the banners, such as "RGB5A3".
+	Format  string        `json:"format"`
+	Banners []BannerEntry `json:"banners"`
+
+	dir    string
+	format tpl.Format
+}
+
+// BannerEntry is a single banner of the manifest.
+type BannerEntry struct {
+	Text   map[string]string `json:"text"`
+	Images map[string]string `json:"images"`
+	// Regions limits the banner to some regions such as "US". An empty list shows it everywhere.
+	Regions []string `json:"regions"`
+}
+
+// LoadBannerManifest reads the banner manifest at path. A missing manifest has no banners.
+func LoadBannerManifest(path string) (*BannerManifest, error) {
+	manifest := &BannerManifest{
+		Width:  BannerWidth,
+		Height: BannerHeight,
+		Format: tpl.RGB5A3.String(),
+		dir:    filepath.Dir(path),
+		format: tpl.RGB5A3,
+	}
+
+	if path == "" {
+		return manifest, nil
+	}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("Banner manifest %s does not exist, csdata.bn will have no banners\n", path)
+		return manifest, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(contents, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	manifest.format, err = tpl.ParseFormat(manifest.Format)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if manifest.Width <= 0 || manifest.Height <= 0 {
+		return nil, fmt.Errorf("parsing %s: banners cannot be %dx%d", path, manifest.Width, manifest.Height)
+	}
+
+	return manifest, nil
+}
+
+// lookup returns the value of values for a region and language.
+func lookup(values map[string]string, region constants.Region, language constants.Language) (string, bool) {
+	for _, key := range []string{region.String() + "/" + language.String(), language.String(), "default"} {
+		if value, ok := values[key]; ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// banner is the text and texture of a banner in a single csdata.bn.
+type banner struct {
+	text    string
+	texture []byte
+}
+
+// banners returns the banners shown in a region and language.
+func (m *BannerManifest) banners(region constants.Region, language constants.Language) ([]banner, error) {
+	var banners []banner
+	for i, entry := range m.Banners {
+		if !entry.showsIn(region) {
+			continue
+		}
+
+		text, ok := lookup(entry.Text, region, language)
+		if !ok {
+			return nil, fmt.Errorf("banner %d has no text for %s/%s", i, region, language)
+		}
+
+		path, ok := lookup(entry.Images, region, language)
+		if !ok {
+			return nil, fmt.Errorf("banner %d has no image for %s/%s", i, region, language)
+		}
+
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(m.dir, path)
+		}
+
+		texture, err := m.texture(path)
+		if err != nil {
+			return nil, fmt.Errorf("banner %d: %s: %w", i, path, err)
+		}
+
+		banners = append(banners, banner{text, texture})
+	}
+
+	if len(banners) > MaxBanners {
+		return nil, fmt.Errorf("%d banners are shown in %s/%s, at most %d fit", len(banners), region, language, MaxBanners)
+	}
+
+	return banners, nil
+}
+
+func (e *BannerEntry) showsIn(region constants.Region) bool {
+	if len(e.Regions) == 0 {
+		return true
+	}
+
+	for _, name := range e.Regions {
+		parsed, err := constants.ParseRegion(name)
+		if err == nil && parsed == region {
+			return true
+		}
+	}
+
+	return false
+}
+
+// texture reads a banner image and returns its texture data.
+func (m *BannerManifest) texture(path string) ([]byte, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".tpl") {
+		texture, err := tpl.DecodeFile(contents)
+		if err != nil {
+			return nil, err
+		}
+
+		if texture.Format != m.format {
+			return nil, fmt.Errorf("texture is %s, banners must be %s", texture.Format, m.format)
+		}
+
+		m.checkSize(path, texture.Width, texture.Height)
+		return texture.Data, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(contents))
+	if err != nil {
+		return nil, err
+	}
+
+	m.checkSize(path, img.Bounds().Dx(), img.Bounds().Dy())
+	return tpl.Encode(img, m.format)
+}
+
+// checkSize warns about a banner image that is not the size of the manifest.
+func (m *BannerManifest) checkSize(path string, width, height int) {
+	if width != m.Width || height != m.Height {
+		fmt.Printf("WARNING: banner %s is %dx%d, banners are expected to be %dx%d\n", path, width, height, m.Width, m.Height)
+	}
+}
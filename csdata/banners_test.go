@@ -0,0 +1,78 @@
+package csdata
+
+import (
+	"NintendoChannel/constants"
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeBanner(t *testing.T, dir string, width, height int) {
+	t.Helper()
+
+	buffer := new(bytes.Buffer)
+	err := png.Encode(buffer, image.NewNRGBA(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "banner.png"), buffer.Bytes(), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBannerSize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "banners.json")
+	err := os.WriteFile(path, []byte(`{"banners": [{"text": {"default": "Hi"}, "images": {"default": "banner.png"}}]}`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := LoadBannerManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if manifest.Width != BannerWidth || manifest.Height != BannerHeight {
+		t.Fatalf("manifest defaults to %dx%d, want %dx%d", manifest.Width, manifest.Height, BannerWidth, BannerHeight)
+	}
+
+	writeBanner(t, dir, BannerWidth, BannerHeight)
+	banners, err := manifest.banners(constants.NTSC, constants.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(banners) != 1 {
+		t.Fatalf("got %d banners, want 1", len(banners))
+	}
+
+	// Other sizes are only warned about, as BannerWidth and BannerHeight are unconfirmed.
+	writeBanner(t, dir, BannerWidth*2, BannerHeight)
+	banners, err = manifest.banners(constants.NTSC, constants.English)
+	if err != nil {
+		t.Fatalf("a banner of another size was rejected: %v", err)
+	}
+
+	if len(banners) != 1 {
+		t.Fatalf("got %d banners of another size, want 1", len(banners))
+	}
+}
+
+func TestBannerSizeUnset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "banners.json")
+	err := os.WriteFile(path, []byte(`{"width": 0, "height": 0, "banners": []}`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = LoadBannerManifest(path)
+	if err == nil {
+		t.Error("a manifest accepting any banner size was loaded")
+	}
+}
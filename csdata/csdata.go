@@ -1,13 +1,17 @@
 package csdata
 
 import (
+	"NintendoChannel/config"
+	"NintendoChannel/constants"
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"github.com/SketchMaster2001/libwc24crypt"
 	"github.com/wii-tools/lzx/lz10"
 	"hash/crc32"
 	"os"
-	// "unicode/utf16"
+	"path/filepath"
+	"unicode/utf16"
 )
 
 type Header struct {
@@ -37,80 +41,119 @@ var (
 	iv  = []byte{70, 70, 20, 40, 143, 110, 36, 6, 184, 107, 135, 239, 96, 45, 80, 151}
 )
 
-func CreateCSData() {
+// CreateCSData writes a csdata.bn for every region and language in regions,
+// to <OutputDir>/dir/6/<region>/<language>/csdata.bn.
+func CreateCSData(cfg *config.Config, regions []constants.RegionMeta) error {
+	manifest, err := LoadBannerManifest(cfg.CSData.BannerManifestPath)
+	if err != nil {
+		return err
+	}
+
+	rsaKey, err := os.ReadFile(cfg.CSData.RSAKeyPath)
+	if err != nil {
+		return fmt.Errorf("reading RSA key: %w", err)
+	}
+
+	for _, region := range regions {
+		for _, language := range region.Languages {
+			banners, err := manifest.banners(region.Region, language)
+			if err != nil {
+				return err
+			}
+
+			contents, err := makeCSData(cfg.ListID, region, language, banners)
+			if err != nil {
+				return err
+			}
+
+			encrypted, err := libwc24crypt.EncryptWC24(contents, key, iv, rsaKey)
+			if err != nil {
+				return fmt.Errorf("encrypting csdata: %w", err)
+			}
+
+			path := Path(cfg.OutputDir, region.Region, language)
+			err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+			if err != nil {
+				return err
+			}
+
+			err = os.WriteFile(path, encrypted, 0666)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Wrote csdata.bn with %d banners - Region: %s, Language: %s\n", len(banners), region.Region, language)
+		}
+	}
+
+	return nil
+}
+
+// Path returns where the csdata.bn for a region and language is written.
+func Path(outputDir string, region constants.Region, language constants.Language) string {
+	return filepath.Join(outputDir, "dir/6", region.String(), language.String(), "csdata.bn")
+}
+
+// makeCSData returns the compressed csdata.bn for a region and language.
+func makeCSData(listID uint32, region constants.RegionMeta, language constants.Language, banners []banner) ([]byte, error) {
 	// First append the DLListID to a
 	var DLListID [256]byte
 	tempID := make([]byte, 256)
 	copy(tempID[:65], "6THqOxqSaiDd5bjhSQS6hk6nkYJVdioanD5Lc8mOHkobUkblWf8KxczDUZwY84FIV")
 	copy(DLListID[:], tempID)
 
+	var supportedLanguages [16]byte
+	for i := range supportedLanguages {
+		supportedLanguages[i] = 255
+	}
+
+	// Every language of the region is supported, even when only some are being generated.
+	for _, meta := range constants.Regions {
+		if meta.Region != region.Region {
+			continue
+		}
+
+		for i, supported := range meta.Languages {
+			supportedLanguages[i] = byte(supported)
+		}
+	}
+
 	header := Header{
 		Version:            6,
 		Unknown:            2,
 		Filesize:           0,
 		CRC32:              0,
-		DLListID:           434968891,
-		CountryCode:        49,
-		LanguageCode:       3,
-		SupportedLanguages: [16]byte{1, 2, 3, 4, 5, 6, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255},
+		DLListID:           listID,
+		CountryCode:        region.CountryCode,
+		LanguageCode:       uint32(language),
+		SupportedLanguages: supportedLanguages,
 		Unknown1:           [12]byte{0, 78, 112, 38, 194, 0, 0, 0, 3, 0, 0, 1},
 		DLUrlID:            DLListID,
 		Unknown2:           222,
 	}
 
-	/*var pics [][]byte
-	pic1, err := os.ReadFile("pic1.tpl")
-	if err != nil {
-		panic(err)
-	}
-
-	pic2, err := os.ReadFile("pic2.tpl")
-	if err != nil {
-		panic(err)
-	}
-
-	pic3, err := os.ReadFile("pic1.tpl")
-	if err != nil {
-		panic(err)
-	}
-
-	pics = append(pics, pic1[64:], pic2[64:], pic3[64:])
-
-	text := []string{"WiiLink Nintendo Channel!", "Demae Channel released", "WiiLink goes global"}
-	for i := 0; i < 3; i++ {
-		var textArray [51]uint16
-		tempText := utf16.Encode([]rune(text[i]))
-		copy(textArray[:], tempText)
-
-		var offset uint32
-		if i == 0 {
-			offset = 640
-		} else if i == 1 {
-			offset = uint32(640 + len(pics[0]))
-		} else if i == 2 {
-			offset = uint32(640 + len(pics[0]) + len(pics[1]))
-		}
+	// The pictures follow the header in the order of the banners.
+	offset := uint32(binary.Size(header))
+	for i, b := range banners {
+		var text [51]uint16
+		copy(text[:], utf16.Encode([]rune(b.text)))
 
 		header.Banners[i] = Banner{
-			Text:          textArray,
-			PictureSize:   uint32(len(pics[i])),
+			Text:          text,
+			PictureSize:   uint32(len(b.texture)),
 			PictureOffset: offset,
 		}
-	}*/
 
-	buffer := new(bytes.Buffer)
+		offset += uint32(len(b.texture))
+	}
 
-	binary.Write(buffer, binary.BigEndian, header)
-	/*binary.Write(buffer, binary.BigEndian, pics[0])
-	binary.Write(buffer, binary.BigEndian, pics[1])
-	binary.Write(buffer, binary.BigEndian, pics[2])*/
-	header.Filesize = uint32(buffer.Len())
-	buffer.Reset()
+	header.Filesize = offset
 
-	binary.Write(buffer, binary.BigEndian, header)
-	/*binary.Write(buffer, binary.BigEndian, pics[0])
-	binary.Write(buffer, binary.BigEndian, pics[1])
-	binary.Write(buffer, binary.BigEndian, pics[2])*/
+	buffer := new(bytes.Buffer)
+	err := writeCSData(buffer, header, banners)
+	if err != nil {
+		return nil, err
+	}
 
 	// Calculate crc32
 	crcTable := crc32.MakeTable(crc32.IEEE)
@@ -118,23 +161,23 @@ func CreateCSData() {
 	header.CRC32 = checksum
 	buffer.Reset()
 
-	binary.Write(buffer, binary.BigEndian, header)
-	/*binary.Write(buffer, binary.BigEndian, pics[0])
-	binary.Write(buffer, binary.BigEndian, pics[1])
-	binary.Write(buffer, binary.BigEndian, pics[2])*/
-
-	compress, err := lz10.Compress(buffer.Bytes())
-
-	rsaKey, err := os.ReadFile("nc.pem")
-	encrypted, err := libwc24crypt.EncryptWC24(compress, key, iv, rsaKey)
+	err = writeCSData(buffer, header, banners)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	err = os.MkdirAll("./dir/6/US/en/", os.ModePerm)
+	return lz10.Compress(buffer.Bytes())
+}
 
-	err = os.WriteFile("./dir/6/US/en/csdata.bn", encrypted, 0666)
+func writeCSData(buffer *bytes.Buffer, header Header, banners []banner) error {
+	err := binary.Write(buffer, binary.BigEndian, header)
 	if err != nil {
-		panic(err)
+		return err
 	}
+
+	for _, b := range banners {
+		buffer.Write(b.texture)
+	}
+
+	return nil
 }
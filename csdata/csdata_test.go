@@ -0,0 +1,31 @@
+package csdata
+
+import (
+	"NintendoChannel/constants"
+	"bytes"
+	"testing"
+)
+
+func TestSupportedLanguagesFiltered(t *testing.T) {
+	// Generating only Dutch must still list every language of PAL.
+	regions := constants.FilterRegions([]constants.Region{constants.PAL}, []constants.Language{constants.Dutch})
+	if len(regions) != 1 {
+		t.Fatalf("FilterRegions returned %d regions", len(regions))
+	}
+
+	compressed, err := makeCSData(1, regions[0], constants.Dutch, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Parse(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	supported := bytes.TrimRight(c.Header.SupportedLanguages[:], "\xff")
+	want := []byte{byte(constants.English), byte(constants.German), byte(constants.French), byte(constants.Spanish), byte(constants.Italian), byte(constants.Dutch)}
+	if !bytes.Equal(supported, want) {
+		t.Errorf("SupportedLanguages is %v, want %v", supported, want)
+	}
+}
@@ -0,0 +1,297 @@
+package csdata
+
+import (
+	"NintendoChannel/dllist"
+	"NintendoChannel/tpl"
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image/png"
+	"math/big"
+	"os"
+	"path/filepath"
+	"unicode/utf16"
+)
+
+// wc24Header is the header of a WiiConnect24 payload, followed by the payload itself.
+type wc24Header struct {
+	Magic     [4]byte
+	Version   uint32
+	_         uint32
+	CryptType uint8
+	_         [35]byte
+	IV        [16]byte
+	Signature [256]byte
+}
+
+// wc24Encrypted is the CryptType of payloads encrypted with AES-128-OFB.
+const wc24Encrypted = 1
+
+// Decrypt reverses the WiiConnect24 encryption of a payload, returning the payload and its signature.
+// Payloads that are not encrypted are returned as they are.
+func Decrypt(contents, aesKey []byte) ([]byte, []byte, error) {
+	var header wc24Header
+	err := binary.Read(bytes.NewReader(contents), binary.BigEndian, &header)
+	if err != nil || string(header.Magic[:]) != "WC24" {
+		return nil, nil, errors.New("not a WiiConnect24 payload")
+	}
+
+	data := contents[binary.Size(header):]
+	if header.CryptType != wc24Encrypted {
+		return data, header.Signature[:], nil
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decrypted := make([]byte, len(data))
+	cipher.NewOFB(block, header.IV[:]).XORKeyStream(decrypted, data)
+	return decrypted, header.Signature[:], nil
+}
+
+// LoadPublicKey reads the RSA key payloads are signed with. It can either be a PEM encoded
+// public or private key, or a channel's wc24pubk.mod, whose first 256 bytes are the modulus.
+func LoadPublicKey(path string) (*rsa.PublicKey, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(contents)
+	if block == nil {
+		if len(contents) != 544 {
+			return nil, fmt.Errorf("%s is neither a PEM key nor a wc24pubk.mod", path)
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(contents[:256]), E: 65537}, nil
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return &key.PublicKey, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if rsaKey, ok := key.(*rsa.PrivateKey); ok {
+			return &rsaKey.PublicKey, nil
+		}
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if rsaKey, ok := key.(*rsa.PublicKey); ok {
+			return rsaKey, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s is not an RSA key", path)
+}
+
+// VerifySignature checks the signature of a decrypted payload.
+func VerifySignature(payload, signature []byte, key *rsa.PublicKey) error {
+	sum := sha1.Sum(payload)
+	return rsa.VerifyPKCS1v15(key, crypto.SHA1, sum[:], signature)
+}
+
+// CSData is a decoded csdata.bn.
+type CSData struct {
+	Header Header
+	// Textures holds the picture of each banner that has one.
+	Textures [][]byte
+	// CRC32 is the checksum of the file, which matches Header.CRC32 if it is intact.
+	CRC32 uint32
+}
+
+// Parse decodes a decrypted csdata.bn, which may be LZ10 compressed.
+func Parse(contents []byte) (*CSData, error) {
+	if len(contents) != 0 && contents[0] == 0x10 {
+		decompressed, err := dllist.Decompress(contents)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing: %w", err)
+		}
+
+		contents = decompressed
+	}
+
+	c := &CSData{}
+	err := binary.Read(bytes.NewReader(contents), binary.BigEndian, &c.Header)
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+
+	// The checksum is calculated with the CRC32 field zeroed.
+	zeroed := append([]byte{}, contents...)
+	binary.BigEndian.PutUint32(zeroed[8:], 0)
+	c.CRC32 = crc32.ChecksumIEEE(zeroed)
+
+	for i, b := range c.Header.Banners {
+		if b.PictureSize == 0 {
+			continue
+		}
+
+		end := uint64(b.PictureOffset) + uint64(b.PictureSize)
+		if end > uint64(len(contents)) {
+			return nil, fmt.Errorf("banner %d picture ends at 0x%X, file is 0x%X bytes", i, end, len(contents))
+		}
+
+		c.Textures = append(c.Textures, contents[b.PictureOffset:end])
+	}
+
+	return c, nil
+}
+
+// bannerText decodes the NUL terminated text of a banner.
+func bannerText(text [51]uint16) string {
+	end := 0
+	for end < len(text) && text[end] != 0 {
+		end++
+	}
+
+	return string(utf16.Decode(text[:end]))
+}
+
+// InspectOptions control what Inspect checks and exports.
+type InspectOptions struct {
+	// PublicKeyPath is the key the signature is verified with. The signature is not verified without one.
+	PublicKeyPath string
+	// ExportDir receives the texture of every banner, and a PNG of it if Width and Height are set.
+	ExportDir string
+	Width     int
+	Height    int
+	Format    tpl.Format
+}
+
+// Inspect decrypts, verifies and prints a csdata.bn.
+func Inspect(path string, options InspectOptions) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	payload, signature, err := Decrypt(contents, key)
+	if err != nil {
+		return err
+	}
+
+	signatureErr := errors.New("not verified")
+	if options.PublicKeyPath != "" {
+		publicKey, err := LoadPublicKey(options.PublicKeyPath)
+		if err != nil {
+			return err
+		}
+
+		signatureErr = VerifySignature(payload, signature, publicKey)
+	}
+
+	c, err := Parse(payload)
+	if err != nil {
+		return err
+	}
+
+	h := c.Header
+	fmt.Printf("Version:             %d\n", h.Version)
+	fmt.Printf("File size:           %d\n", h.Filesize)
+	fmt.Printf("CRC32:               0x%08X (calculated 0x%08X)\n", h.CRC32, c.CRC32)
+	fmt.Printf("DL list ID:          %d\n", h.DLListID)
+	fmt.Printf("Country code:        %d\n", h.CountryCode)
+	fmt.Printf("Language code:       %d\n", h.LanguageCode)
+	fmt.Printf("Supported languages: %v\n", bytes.TrimRight(h.SupportedLanguages[:], "\xff"))
+	fmt.Printf("DL URL ID:           %s\n", bytes.TrimRight(h.DLUrlID[:], "\x00"))
+	if signatureErr != nil {
+		fmt.Printf("Signature:           %v\n", signatureErr)
+	} else {
+		fmt.Println("Signature:           OK")
+	}
+
+	texture := 0
+	for i, b := range h.Banners {
+		fmt.Printf("Banner %d:            %q, picture at 0x%X, 0x%X bytes\n", i, bannerText(b.Text), b.PictureOffset, b.PictureSize)
+		if b.PictureSize == 0 || options.ExportDir == "" {
+			continue
+		}
+
+		err = exportBanner(options, i, c.Textures[texture])
+		if err != nil {
+			return fmt.Errorf("exporting banner %d: %w", i, err)
+		}
+
+		texture++
+	}
+
+	if h.CRC32 != c.CRC32 {
+		return errors.New("CRC32 does not match")
+	} else if options.PublicKeyPath != "" && signatureErr != nil {
+		return fmt.Errorf("signature: %w", signatureErr)
+	}
+
+	return nil
+}
+
+func exportBanner(options InspectOptions, index int, texture []byte) error {
+	err := os.MkdirAll(options.ExportDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	base := filepath.Join(options.ExportDir, fmt.Sprintf("banner%d", index))
+	err = os.WriteFile(base+".bin", texture, 0666)
+	if err != nil || options.Width == 0 || options.Height == 0 {
+		return err
+	}
+
+	img, err := tpl.Decode(texture, options.Width, options.Height, options.Format)
+	if err != nil {
+		return err
+	}
+
+	buffer := new(bytes.Buffer)
+	err = png.Encode(buffer, img)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(base+".png", buffer.Bytes(), 0666)
+}
+
+// DecryptFile writes the decrypted and decompressed contents of a csdata.bn to output.
+func DecryptFile(path, output string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	payload, _, err := Decrypt(contents, key)
+	if err != nil {
+		return err
+	}
+
+	decompressed, err := dllist.Decompress(payload)
+	if err != nil {
+		return fmt.Errorf("decompressing: %w", err)
+	}
+
+	return os.WriteFile(output, decompressed, 0666)
+}
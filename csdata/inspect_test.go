@@ -0,0 +1,45 @@
+package csdata
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/dllist"
+	"errors"
+	"testing"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	compressed, err := makeCSData(1, constants.Regions[2], constants.German, []banner{{text: "Banner", texture: make([]byte, 64)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Parse(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Header.CRC32 != c.CRC32 {
+		t.Errorf("CRC32 is 0x%08X, calculated 0x%08X", c.Header.CRC32, c.CRC32)
+	}
+
+	if c.Header.CountryCode != constants.Regions[2].CountryCode || c.Header.LanguageCode != uint32(constants.German) {
+		t.Errorf("country %d and language %d, want %d and %d", c.Header.CountryCode, c.Header.LanguageCode, constants.Regions[2].CountryCode, constants.German)
+	}
+
+	if len(c.Textures) != 1 || len(c.Textures[0]) != 64 || bannerText(c.Header.Banners[0].Text) != "Banner" {
+		t.Errorf("banners were not read back: %+v", c.Header.Banners)
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	compressed, err := makeCSData(1, constants.Regions[1], constants.English, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A cut off LZ10 stream makes lz10.Decompress panic, which Parse must turn into an error.
+	_, err = Parse(compressed[:len(compressed)/2])
+	if !errors.Is(err, dllist.ErrLZ10Truncated) {
+		t.Errorf("Parse of a truncated file = %v, want %v", err, dllist.ErrLZ10Truncated)
+	}
+}
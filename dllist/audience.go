@@ -0,0 +1,236 @@
+package dllist
+
+import (
+	"NintendoChannel/gametdb"
+	"NintendoChannel/store"
+	"fmt"
+)
+
+// AudienceGroups is the number of age and gender groups the channel charts who plays a title for.
+const AudienceGroups = 12
+
+const (
+	// hardcoreUnknownBits and friendsUnknownBits are the bits of unknown purpose set in every
+	// title of Nintendo's lists, which we copy.
+	hardcoreUnknownBits = 0x20000800
+	friendsUnknownBits  = 0xA8
+	flagsUnknownBits    = 0xA8
+
+	// minSurveyResponses is how many players of a group must answer before their answers are used.
+	minSurveyResponses = 3
+	// hardcoreTimePerPlayer is the play time per player, in minutes, above which a title
+	// without survey answers is considered hardcore.
+	hardcoreTimePerPlayer = 10 * 60
+)
+
+// Audience describes who plays a title. Each array has an entry per age and gender group.
+// In the bitfields, a group's bit is cleared when the answer applies, like TitleFlags.
+type Audience struct {
+	// Hardcore is set for groups who consider the title hardcore rather than casual.
+	Hardcore    [AudienceGroups]bool
+	AllHardcore bool
+	// Gamers is set for groups who consider the title for gamers rather than everyone.
+	Gamers    [AudienceGroups]bool
+	AllGamers bool
+	// WithFriends is set for groups who play the title with friends rather than alone.
+	WithFriends    [AudienceGroups]bool
+	AllWithFriends bool
+}
+
+// HardcoreBitField holds pairs of an unknown bit and the casual (1) or hardcore (0) bit of each
+// audience group, most significant first, followed by 7 unknown bits and the bit for all groups.
+type HardcoreBitField uint32
+
+// FriendsBitField holds pairs of the everyone (1) or gamers (0) bit and the alone (1) or with friends (0) bit
+// of each audience group, most significant first, followed by 6 unknown bits and the bits for all groups.
+type FriendsBitField uint32
+
+// TitleFlags holds 5 unknown bits, 0xA8 in every title of Nintendo's lists, followed by the
+// online, video and multiplayer flags. A flag is cleared when it applies to the title.
+type TitleFlags uint8
+
+const (
+	flagOnline      TitleFlags = 1 << 2
+	flagVideo       TitleFlags = 1 << 1
+	flagMultiplayer TitleFlags = 1 << 0
+)
+
+// groupBit returns the bit at shift for an answer, which is cleared when the answer applies.
+func groupBit(applies bool, shift int) uint32 {
+	if applies {
+		return 0
+	}
+
+	return 1 << shift
+}
+
+// HardcoreBitField encodes the casual or hardcore answers of the audience.
+func (a *Audience) HardcoreBitField() HardcoreBitField {
+	bits := uint32(hardcoreUnknownBits)
+	for group, hardcore := range a.Hardcore {
+		bits |= groupBit(hardcore, 30-2*group)
+	}
+
+	return HardcoreBitField(bits | groupBit(a.AllHardcore, 0))
+}
+
+// FriendsBitField encodes the everyone or gamers and alone or with friends answers of the audience.
+func (a *Audience) FriendsBitField() FriendsBitField {
+	bits := uint32(friendsUnknownBits)
+	for group := 0; group < AudienceGroups; group++ {
+		bits |= groupBit(a.Gamers[group], 31-2*group)
+		bits |= groupBit(a.WithFriends[group], 30-2*group)
+	}
+
+	return FriendsBitField(bits | groupBit(a.AllGamers, 1) | groupBit(a.AllWithFriends, 0))
+}
+
+// DecodeAudience reads the audience of a title back from its bitfields.
+func DecodeAudience(hardcore HardcoreBitField, friends FriendsBitField) Audience {
+	var a Audience
+	for group := 0; group < AudienceGroups; group++ {
+		a.Hardcore[group] = hardcore&(1<<(30-2*group)) == 0
+		a.Gamers[group] = friends&(1<<(31-2*group)) == 0
+		a.WithFriends[group] = friends&(1<<(30-2*group)) == 0
+	}
+
+	a.AllHardcore = hardcore&1 == 0
+	a.AllGamers = friends&2 == 0
+	a.AllWithFriends = friends&1 == 0
+	return a
+}
+
+// NewTitleFlags encodes whether a title can be played online, has a video and supports multiplayer.
+func NewTitleFlags(online, video, multiplayer bool) TitleFlags {
+	flags := flagsUnknownBits | flagOnline | flagVideo | flagMultiplayer
+	if online {
+		flags &^= flagOnline
+	}
+
+	if video {
+		flags &^= flagVideo
+	}
+
+	if multiplayer {
+		flags &^= flagMultiplayer
+	}
+
+	return flags
+}
+
+func (f TitleFlags) Online() bool      { return f&flagOnline == 0 }
+func (f TitleFlags) Video() bool       { return f&flagVideo == 0 }
+func (f TitleFlags) Multiplayer() bool { return f&flagMultiplayer == 0 }
+
+// audienceData is what we know about the players of a game.
+type audienceData struct {
+	groups [AudienceGroups]store.Survey
+	total  store.Survey
+	// timePerPlayer is the average play time of a player in minutes, or 0 if nobody has played.
+	timePerPlayer int
+}
+
+// loadAudiences reads the survey answers and play time of every game, keyed by the first 4 characters of its ID.
+func loadAudiences(s store.Store) (map[string]*audienceData, error) {
+	audiences := map[string]*audienceData{}
+	get := func(gameID string) *audienceData {
+		if len(gameID) > 4 {
+			gameID = gameID[:4]
+		}
+
+		if _, ok := audiences[gameID]; !ok {
+			audiences[gameID] = &audienceData{}
+		}
+
+		return audiences[gameID]
+	}
+
+	surveys, err := s.Survey()
+	if err != nil {
+		return nil, fmt.Errorf("querying survey: %w", err)
+	}
+
+	for _, survey := range surveys {
+		if survey.Group < 0 || survey.Group >= AudienceGroups {
+			continue
+		}
+
+		data := get(survey.GameID)
+		addSurvey(&data.groups[survey.Group], survey)
+		addSurvey(&data.total, survey)
+	}
+
+	timePlayed, err := s.TimePlayed()
+	if err != nil {
+		return nil, fmt.Errorf("querying time played: %w", err)
+	}
+
+	for _, played := range timePlayed {
+		if played.NumberOfPlayers == 0 {
+			continue
+		}
+
+		get(played.GameID).timePerPlayer = played.TimePlayed / played.NumberOfPlayers
+	}
+
+	return audiences, nil
+}
+
+func addSurvey(sum *store.Survey, survey store.Survey) {
+	sum.Responses += survey.Responses
+	sum.Hardcore += survey.Hardcore
+	sum.Gamers += survey.Gamers
+	sum.WithFriends += survey.WithFriends
+}
+
+// majority reports whether most of the players who answered said yes, or ok = false if too few answered.
+func majority(yes, responses int) (answer, ok bool) {
+	if responses < minSurveyResponses {
+		return false, false
+	}
+
+	return yes*2 > responses, true
+}
+
+// MakeAudience returns who plays a game. Survey answers are used for every group that has
+// enough of them. Other groups fall back to the play time of the game for casual or hardcore,
+// and to the player counts in GameTDB for alone or with friends.
+func (l *List) MakeAudience(game *gametdb.Game) Audience {
+	data := l.audiences[game.ID[:4]]
+	if data == nil {
+		data = &audienceData{}
+	}
+
+	hardcore := data.timePerPlayer >= hardcoreTimePerPlayer
+	// Nintendo's lists mark every group as everyone rather than gamers, which we keep when nobody has answered.
+	gamers := false
+	withFriends := game.Controllers.Players > 1 || game.Features.OnlinePlayers > 0
+
+	answer := func(yes, responses int, fallback bool) bool {
+		if answer, ok := majority(yes, responses); ok {
+			return answer
+		}
+
+		return fallback
+	}
+
+	var a Audience
+	for group, survey := range data.groups {
+		a.Hardcore[group] = answer(survey.Hardcore, survey.Responses, hardcore)
+		a.Gamers[group] = answer(survey.Gamers, survey.Responses, gamers)
+		a.WithFriends[group] = answer(survey.WithFriends, survey.Responses, withFriends)
+	}
+
+	a.AllHardcore = answer(data.total.Hardcore, data.total.Responses, hardcore)
+	a.AllGamers = answer(data.total.Gamers, data.total.Responses, gamers)
+	a.AllWithFriends = answer(data.total.WithFriends, data.total.Responses, withFriends)
+	return a
+}
+
+// MakeTitleFlags returns the flags of a game from its GameTDB features.
+// We have no videos linked to titles yet, so the video flag is never set.
+func MakeTitleFlags(game *gametdb.Game) TitleFlags {
+	online := game.Features.OnlinePlayers > 0 || game.Features.Has("online")
+	multiplayer := game.Controllers.Players > 1 || online
+	return NewTitleFlags(online, false, multiplayer)
+}
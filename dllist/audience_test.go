@@ -0,0 +1,56 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"bytes"
+	"testing"
+)
+
+func TestAudienceRoundTrip(t *testing.T) {
+	list, err := Decode(bytes.NewReader(constants.DLList))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(list.TitleTable) == 0 {
+		t.Fatal("no titles in constants.DLList")
+	}
+
+	for _, title := range list.TitleTable {
+		audience := DecodeAudience(title.HardcoreBitField, title.FriendsBitField)
+		if hardcore := audience.HardcoreBitField(); hardcore != title.HardcoreBitField {
+			t.Fatalf("title %X: HardcoreBitField is %08X, re-encoded as %08X", title.ID, uint32(title.HardcoreBitField), uint32(hardcore))
+		}
+
+		if friends := audience.FriendsBitField(); friends != title.FriendsBitField {
+			t.Fatalf("title %X: FriendsBitField is %08X, re-encoded as %08X", title.ID, uint32(title.FriendsBitField), uint32(friends))
+		}
+	}
+}
+
+func TestAudiencePolarity(t *testing.T) {
+	// A group's bit is cleared when the answer applies.
+	var audience Audience
+	audience.Hardcore[0] = true
+	audience.Gamers[1] = true
+	audience.WithFriends[2] = true
+	audience.AllWithFriends = true
+
+	hardcore := uint32(audience.HardcoreBitField())
+	if hardcore&(1<<30) != 0 || hardcore&(1<<28) == 0 || hardcore&1 == 0 {
+		t.Errorf("HardcoreBitField %08X: want group 0 hardcore and every other group casual", hardcore)
+	}
+
+	friends := uint32(audience.FriendsBitField())
+	if friends&(1<<29) != 0 || friends&(1<<31) == 0 {
+		t.Errorf("FriendsBitField %08X: want group 1 gamers and group 0 everyone", friends)
+	}
+
+	if friends&(1<<26) != 0 || friends&(1<<30) == 0 || friends&1 != 0 {
+		t.Errorf("FriendsBitField %08X: want group 2 and all groups with friends, group 0 alone", friends)
+	}
+
+	if decoded := DecodeAudience(HardcoreBitField(hardcore), FriendsBitField(friends)); decoded != audience {
+		t.Errorf("decoded %+v, want %+v", decoded, audience)
+	}
+}
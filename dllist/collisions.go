@@ -0,0 +1,151 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/gametdb"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Kinds of Collision.
+const (
+	// CollisionDuplicateID is a title whose list ID was already taken. It is given the next free ID.
+	CollisionDuplicateID = "duplicate_id"
+	// CollisionCrossPlatform is a 4 character game ID used on more than one platform.
+	// Both titles are kept, as their list IDs differ by platform.
+	CollisionCrossPlatform = "cross_platform"
+	// CollisionRegionVariant is a second game with the same 4 character ID on the same platform,
+	// such as a release by another publisher. Only the first is kept.
+	CollisionRegionVariant = "region_variant"
+)
+
+// Collision is a title that clashed with another when assigning list IDs.
+type Collision struct {
+	Kind string `json:"kind"`
+	// Title and Other are the clashing titles as "<database>/<game ID>". Other came first.
+	Title      string `json:"title"`
+	Other      string `json:"other"`
+	Resolution string `json:"resolution"`
+}
+
+// titleSource is a GameTDB database titles are generated from.
+type titleSource struct {
+	games            []gametdb.Game
+	defaultTitleType constants.TitleType
+}
+
+// listTitleID returns the ID of a game in TitleTable, before collisions are resolved.
+func listTitleID(gameID string, defaultTitleType constants.TitleType) uint32 {
+	var titleID [4]byte
+	copy(titleID[:], gameID)
+
+	// Wii, DS and 3DS games may share the same IDs are one another. XOR to avoid conflict.
+	id := binary.BigEndian.Uint32(titleID[:])
+	if defaultTitleType == constants.NintendoDS {
+		id ^= 0x22222222
+	} else if defaultTitleType == constants.NintendoThreeDS {
+		id ^= 0x33333333
+	}
+
+	return id
+}
+
+// resolveTitleIDs assigns every title of the list a unique ID before any is generated, so that
+// info files, which are named after it, never overwrite each other. Titles are visited in the
+// order they are generated, so the first title to claim an ID or game ID always keeps it.
+// The collisions found are written to CollisionsPath.
+func (l *List) resolveTitleIDs() error {
+	l.titleIDs = map[*gametdb.Game]uint32{}
+	var collisions []Collision
+
+	taken := map[uint32]string{}
+	variants := map[string]string{}
+	platforms := map[string]string{}
+	sources := []titleSource{
+		{gametdb.WiiTDB.Games, constants.Wii},
+		{gametdb.DSTDB.Games, constants.NintendoDS},
+		{gametdb.ThreeDSTDB.Games, constants.NintendoThreeDS},
+	}
+
+	for _, source := range sources {
+		database := titleDatabases[source.defaultTitleType]
+		for i := range source.games {
+			game := &source.games[i]
+			if !l.isForRegion(game) {
+				continue
+			}
+
+			if !includesTitle(game, getTitleType(game, source.defaultTitleType)) {
+				continue
+			}
+
+			key := database + "/" + game.ID
+			shortID := game.ID
+			if len(shortID) > 4 {
+				shortID = shortID[:4]
+			}
+
+			if other, ok := variants[database+"/"+shortID]; ok {
+				collisions = append(collisions, Collision{CollisionRegionVariant, key, other, "skipped"})
+				continue
+			}
+
+			variants[database+"/"+shortID] = key
+
+			if other, ok := platforms[shortID]; ok {
+				collisions = append(collisions, Collision{CollisionCrossPlatform, key, other, "kept"})
+			} else {
+				platforms[shortID] = key
+			}
+
+			id := listTitleID(game.ID, source.defaultTitleType)
+			if other, ok := taken[id]; ok {
+				for _, ok = taken[id]; ok; _, ok = taken[id] {
+					id++
+				}
+
+				collisions = append(collisions, Collision{CollisionDuplicateID, key, other, fmt.Sprintf("ID changed to %08X", id)})
+			}
+
+			taken[id] = key
+			l.titleIDs[game] = id
+		}
+	}
+
+	if len(collisions) != 0 {
+		fmt.Printf("Resolved %d title collisions - Region: %s, Language: %s\n", len(collisions), l.region, l.language)
+	}
+
+	return l.writeCollisions(collisions)
+}
+
+// CollisionsPath returns where the collisions found while generating a list are reported.
+func CollisionsPath(outputDir string, region constants.Region, language constants.Language) string {
+	return filepath.Join(outputDir, fmt.Sprintf("reports/%d/%d/collisions.json", region, language))
+}
+
+func (l *List) writeCollisions(collisions []Collision) error {
+	if collisions == nil {
+		collisions = []Collision{}
+	}
+
+	return writeReport(CollisionsPath(l.config.OutputDir, l.region, l.language), collisions)
+}
+
+// writeReport writes v as indented JSON to path.
+func writeReport(path string, v any) error {
+	contents, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, contents, 0666)
+}
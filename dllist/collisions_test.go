@@ -0,0 +1,145 @@
+package dllist
+
+import (
+	"NintendoChannel/config"
+	"NintendoChannel/constants"
+	"NintendoChannel/gametdb"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// setGameTDB replaces the loaded GameTDB databases with games for the rest of a test.
+func setGameTDB(t *testing.T, wii, ds, threeDS []gametdb.Game) {
+	t.Helper()
+
+	previous := []*gametdb.GameTDB{gametdb.WiiTDB, gametdb.DSTDB, gametdb.ThreeDSTDB}
+	t.Cleanup(func() {
+		gametdb.WiiTDB, gametdb.DSTDB, gametdb.ThreeDSTDB = previous[0], previous[1], previous[2]
+	})
+
+	gametdb.WiiTDB = &gametdb.GameTDB{Games: wii}
+	gametdb.DSTDB = &gametdb.GameTDB{Games: ds}
+	gametdb.ThreeDSTDB = &gametdb.GameTDB{Games: threeDS}
+}
+
+func usGames(ids ...string) []gametdb.Game {
+	var games []gametdb.Game
+	for _, id := range ids {
+		games = append(games, gametdb.Game{ID: id, Region: "NTSC-U"})
+	}
+
+	return games
+}
+
+func TestResolveTitleIDs(t *testing.T) {
+	// The DS IDs below are the Wii IDs XORed with 0x22222222, so they start out with the same list ID.
+	for _, test := range []struct {
+		name    string
+		wii, ds []gametdb.Game
+		// ids are the expected list IDs by "<database>/<game ID>". Titles missing from it are skipped.
+		ids        map[string]uint32
+		collisions []string
+	}{
+		{
+			name: "no collisions",
+			wii:  usGames("RMCE", "SMNE"),
+			ds:   usGames("AMCE"),
+			ids: map[string]uint32{
+				"wiitdb/RMCE": 0x524D4345,
+				"wiitdb/SMNE": 0x534D4E45,
+				"dstdb/AMCE":  0x414D4345 ^ 0x22222222,
+			},
+		},
+		{
+			name: "colliding IDs",
+			wii:  usGames("ABCE"),
+			ds:   usGames("c`ag"),
+			ids: map[string]uint32{
+				"wiitdb/ABCE": 0x41424345,
+				"dstdb/c`ag":  0x41424346,
+			},
+			collisions: []string{CollisionDuplicateID},
+		},
+		{
+			name: "run of consecutive collisions",
+			wii:  usGames("ABCE", "ABCF", "ABCG"),
+			ds:   usGames("c`ag"),
+			ids: map[string]uint32{
+				"wiitdb/ABCE": 0x41424345,
+				"wiitdb/ABCF": 0x41424346,
+				"wiitdb/ABCG": 0x41424347,
+				"dstdb/c`ag":  0x41424348,
+			},
+			collisions: []string{CollisionDuplicateID},
+		},
+		{
+			name: "region variants",
+			wii:  usGames("RMCE01", "RMCE52", "SMNE01"),
+			ids: map[string]uint32{
+				"wiitdb/RMCE01": 0x524D4345,
+				"wiitdb/SMNE01": 0x534D4E45,
+			},
+			collisions: []string{CollisionRegionVariant},
+		},
+		{
+			name: "same game ID on two platforms",
+			wii:  usGames("ABCE"),
+			ds:   usGames("ABCE"),
+			ids: map[string]uint32{
+				"wiitdb/ABCE": 0x41424345,
+				"dstdb/ABCE":  0x41424345 ^ 0x22222222,
+			},
+			collisions: []string{CollisionCrossPlatform},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			setGameTDB(t, test.wii, test.ds, nil)
+			l := &List{region: constants.NTSC, language: constants.English, config: &config.Config{OutputDir: t.TempDir()}}
+			err := l.resolveTitleIDs()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := map[string]uint32{}
+			for database, tdb := range map[string]*gametdb.GameTDB{"wiitdb": gametdb.WiiTDB, "dstdb": gametdb.DSTDB} {
+				for j := range tdb.Games {
+					if id, ok := l.titleIDs[&tdb.Games[j]]; ok {
+						ids[database+"/"+tdb.Games[j].ID] = id
+					}
+				}
+			}
+
+			if len(ids) != len(test.ids) {
+				t.Errorf("IDs are %X, want %X", ids, test.ids)
+			}
+
+			for key, want := range test.ids {
+				if got, ok := ids[key]; !ok || got != want {
+					t.Errorf("%s has ID %08X, want %08X", key, got, want)
+				}
+			}
+
+			contents, err := os.ReadFile(CollisionsPath(l.config.OutputDir, l.region, l.language))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var collisions []Collision
+			err = json.Unmarshal(contents, &collisions)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(collisions) != len(test.collisions) {
+				t.Fatalf("collisions are %+v, want kinds %v", collisions, test.collisions)
+			}
+
+			for j, collision := range collisions {
+				if collision.Kind != test.collisions[j] {
+					t.Errorf("collision %d is %s, want %s", j, collision.Kind, test.collisions[j])
+				}
+			}
+		})
+	}
+}
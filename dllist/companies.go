@@ -0,0 +1,171 @@
+package dllist
+
+import (
+	"NintendoChannel/gametdb"
+	"NintendoChannel/layout"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// defaultCompanyID is Nintendo's maker code "01". Titles whose company is unknown are credited to it.
+const defaultCompanyID = 12337
+
+// companySuffixes are dropped when comparing company names, so "Nintendo Co., Ltd." matches "Nintendo".
+var companySuffixes = map[string]bool{
+	"co":          true,
+	"corp":        true,
+	"corporation": true,
+	"gmbh":        true,
+	"inc":         true,
+	"llc":         true,
+	"limited":     true,
+	"ltd":         true,
+	"plc":         true,
+	"sa":          true,
+}
+
+// companyKey identifies a CompanyTable entry by its normalized developer and publisher.
+type companyKey struct {
+	developer string
+	publisher string
+}
+
+// companyResolver assigns every developer and publisher pair in GameTDB a CompanyTable entry.
+type companyResolver struct {
+	companies []gametdb.Company
+	byCode    map[string]gametdb.Company
+	byName    map[string]gametdb.Company
+	entries   map[companyKey]int
+	table     []CompanyTable
+}
+
+func newCompanyResolver(companies []gametdb.Company) *companyResolver {
+	r := &companyResolver{
+		companies: companies,
+		byCode:    map[string]gametdb.Company{},
+		byName:    map[string]gametdb.Company{},
+		entries:   map[companyKey]int{},
+	}
+
+	for _, company := range companies {
+		r.byCode[company.Code] = company
+		name := normalizeCompany(company.Name)
+		if _, ok := r.byName[name]; !ok {
+			r.byName[name] = company
+		}
+	}
+
+	// The first entry is the default every unresolved title points to.
+	r.add(companyKey{"nintendo", "nintendo"}, CompanyTable{
+		CompanyID:     defaultCompanyID,
+		DeveloperName: layout.Encode("Nintendo"),
+		PublisherName: layout.Encode("Nintendo"),
+	})
+
+	return r
+}
+
+// add returns the index of the entry for key, appending entry if there is none yet.
+func (r *companyResolver) add(key companyKey, entry CompanyTable) int {
+	if i, ok := r.entries[key]; ok {
+		return i
+	}
+
+	r.entries[key] = len(r.table)
+	r.table = append(r.table, entry)
+	return len(r.table) - 1
+}
+
+// entry builds the CompanyTable entry of a game. It reports false if the publisher of the game is unknown.
+func (r *companyResolver) entry(game *gametdb.Game) (companyKey, CompanyTable, bool) {
+	publisher := strings.TrimSpace(game.Publisher)
+
+	// The maker code of a disc game is the most accurate way of finding its company.
+	company, ok := gametdb.Company{}, false
+	if len(game.ID) > 4 {
+		company, ok = r.byCode[game.ID[4:]]
+	}
+
+	if !ok && publisher != "" {
+		company, ok = r.find(publisher)
+	}
+
+	if publisher == "" {
+		if !ok {
+			return companyKey{}, CompanyTable{}, false
+		}
+
+		publisher = company.Name
+	}
+
+	// Publishers missing from the GameTDB company list get an ID derived from their name.
+	companyID := syntheticCompanyID(normalizeCompany(publisher))
+	if ok {
+		companyID = companyCodeID(company.Code)
+	}
+
+	developer := strings.TrimSpace(game.Developer)
+	if developer == "" {
+		developer = publisher
+	}
+
+	key := companyKey{normalizeCompany(developer), normalizeCompany(publisher)}
+	return key, CompanyTable{
+		CompanyID:     companyID,
+		DeveloperName: layout.Encode(developer),
+		PublisherName: layout.Encode(publisher),
+	}, true
+}
+
+// find looks a company up by name, falling back to a company whose whole name appears in it.
+func (r *companyResolver) find(name string) (gametdb.Company, bool) {
+	name = normalizeCompany(name)
+	if company, ok := r.byName[name]; ok {
+		return company, true
+	}
+
+	for _, company := range r.companies {
+		companyName := normalizeCompany(company.Name)
+		if companyName != "" && strings.Contains(" "+name+" ", " "+companyName+" ") {
+			return company, true
+		}
+	}
+
+	return gametdb.Company{}, false
+}
+
+// normalizeCompany lowercases name and strips punctuation and legal suffixes.
+func normalizeCompany(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for len(words) > 1 && companySuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+
+	return strings.Join(words, " ")
+}
+
+// companyCodeID converts a maker code such as "01" to the big endian integer of its characters.
+func companyCodeID(code string) uint32 {
+	if len(code) > 4 {
+		return syntheticCompanyID(code)
+	}
+
+	var id uint32
+	for _, c := range []byte(code) {
+		id = id<<8 | uint32(c)
+	}
+
+	return id
+}
+
+// syntheticCompanyID derives a stable ID from a company name.
+// The high bit keeps it apart from the IDs of real maker codes.
+func syntheticCompanyID(name string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return h.Sum32() | 0x80000000
+}
@@ -0,0 +1,193 @@
+package dllist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/wii-tools/lzx/lz10"
+	"io"
+)
+
+var (
+	ErrTruncated     = errors.New("dllist: file is truncated")
+	ErrInvalidOffset = errors.New("dllist: offset does not point to a record")
+	// ErrLZ10Truncated is returned by Decompress for LZ10 streams that end early.
+	ErrLZ10Truncated = errors.New("LZ10 stream ended early")
+)
+
+// Sizes of the records found in dllist.bin.
+var (
+	headerSize                    = binary.Size(Header{})
+	ratingTableSize               = binary.Size(RatingTable{})
+	titleTypeTableSize            = binary.Size(TitleTypeTable{})
+	companyTableSize              = binary.Size(CompanyTable{})
+	titleTableSize                = binary.Size(TitleTable{})
+	videoTableSize                = binary.Size(VideoTable{})
+	newVideoTableSize             = binary.Size(NewVideoTable{})
+	demoTableSize                 = binary.Size(DemoTable{})
+	recentRecommendationTableSize = binary.Size(RecentRecommendationTable{})
+	popularVideosTableSize        = binary.Size(PopularVideosTable{})
+	detailedRatingTableSize       = binary.Size(DetailedRatingTable{})
+)
+
+// Decode reads a dllist.bin from an io.Reader. The file may either be raw or LZ10 compressed.
+func Decode(reader io.Reader) (*List, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != 0 && data[0] == lz10.FileMagic {
+		data, err = Decompress(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTruncated, err)
+		}
+	}
+
+	return DecodeBytes(data)
+}
+
+// DecodeBytes parses an already decompressed dllist.bin.
+func DecodeBytes(data []byte) (*List, error) {
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("%w: header needs %d bytes, got %d", ErrTruncated, headerSize, len(data))
+	}
+
+	l := &List{raw: data}
+	err := binary.Read(bytes.NewReader(data), binary.BigEndian, &l.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &l.Header
+	tables := []struct {
+		name   string
+		count  uint32
+		offset uint32
+		size   int
+		dest   any
+	}{
+		{"ratings", h.NumberOfRatingTables, h.RatingTableOffset, ratingTableSize, &l.RatingsTable},
+		{"title types", h.NumberOfTitleTypeTables, h.TitleTypeTableOffset, titleTypeTableSize, &l.TitleTypesTable},
+		{"companies", h.NumberOfCompanyTables, h.CompanyTableOffset, companyTableSize, &l.CompaniesTable},
+		{"titles", h.NumberOfTitleTables, h.TitleTableOffset, titleTableSize, &l.TitleTable},
+		{"new titles", h.NumberOfNewTitleTables, h.NewTitleTableOffset, 4, &l.NewTitleTable},
+		{"videos", h.NumberOfVideoTables, h.VideoTableOffset, videoTableSize, &l.VideoTable},
+		{"new videos", h.NumberOfNewVideoTables, h.NewVideoTableOffset, newVideoTableSize, &l.NewVideoTable},
+		{"demos", h.NumberOfDemoTables, h.DemoTableOffset, demoTableSize, &l.DemoTable},
+		{"recommendations", h.NumberOfRecommendationTables, h.RecommendationTableOffset, 4, &l.RecommendationTable},
+		{"recent recommendations", h.NumberOfRecentRecommendationTables, h.RecentRecommendationTableOffset, recentRecommendationTableSize, &l.RecentRecommendationTable},
+		{"popular videos", h.NumberOfPopularVideoTables, h.PopularVideoTableOffset, popularVideosTableSize, &l.PopularVideosTable},
+		{"detailed ratings", h.NumberOfDetailedRatingTables, h.DetailedRatingTablesOffset, detailedRatingTableSize, &l.DetailedRatingTable},
+	}
+
+	for _, table := range tables {
+		end := uint64(table.offset) + uint64(table.count)*uint64(table.size)
+		if end > uint64(len(data)) {
+			return nil, fmt.Errorf("%w: %s table (%d entries at 0x%X) ends at 0x%X, file is 0x%X bytes", ErrTruncated, table.name, table.count, table.offset, end, len(data))
+		}
+
+		err = readTable(data[table.offset:end], table.count, table.dest)
+		if err != nil {
+			return nil, fmt.Errorf("dllist: reading %s table: %w", table.name, err)
+		}
+	}
+
+	return l, nil
+}
+
+// readTable allocates a slice of count entries into dest, then fills it from data.
+func readTable(data []byte, count uint32, dest any) error {
+	switch d := dest.(type) {
+	case *[]RatingTable:
+		*d = make([]RatingTable, count)
+	case *[]TitleTypeTable:
+		*d = make([]TitleTypeTable, count)
+	case *[]CompanyTable:
+		*d = make([]CompanyTable, count)
+	case *[]TitleTable:
+		*d = make([]TitleTable, count)
+	case *[]uint32:
+		*d = make([]uint32, count)
+	case *[]VideoTable:
+		*d = make([]VideoTable, count)
+	case *[]NewVideoTable:
+		*d = make([]NewVideoTable, count)
+	case *[]DemoTable:
+		*d = make([]DemoTable, count)
+	case *[]RecentRecommendationTable:
+		*d = make([]RecentRecommendationTable, count)
+	case *[]PopularVideosTable:
+		*d = make([]PopularVideosTable, count)
+	case *[]DetailedRatingTable:
+		*d = make([]DetailedRatingTable, count)
+	default:
+		return fmt.Errorf("unsupported table type %T", dest)
+	}
+
+	return binary.Read(bytes.NewReader(data), binary.BigEndian, dest)
+}
+
+// Decompress wraps lz10.Decompress, which panics on truncated input, returning ErrLZ10Truncated instead.
+// Every reader of LZ10 compressed files should use it, so a corrupt file cannot crash the CLI.
+func Decompress(data []byte) (decompressed []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			decompressed = nil
+			err = ErrLZ10Truncated
+		}
+	}()
+
+	return lz10.Decompress(data)
+}
+
+// Company resolves an offset such as TitleTable.CompanyOffset to its CompanyTable entry.
+func (l *List) Company(offset uint32) (*CompanyTable, error) {
+	i, err := l.Header.index(offset, l.Header.CompanyTableOffset, l.Header.NumberOfCompanyTables, companyTableSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l.CompaniesTable[i], nil
+}
+
+// Title resolves an offset such as an entry of NewTitleTable to its TitleTable entry.
+func (l *List) Title(offset uint32) (*TitleTable, error) {
+	i, err := l.Header.index(offset, l.Header.TitleTableOffset, l.Header.NumberOfTitleTables, titleTableSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &l.TitleTable[i], nil
+}
+
+// RatingImage returns the JPEG referenced by the rating at the given index of RatingsTable.
+// It is only available on decoded lists.
+func (l *List) RatingImage(index int) ([]byte, error) {
+	if index < 0 || index >= len(l.RatingsTable) {
+		return nil, fmt.Errorf("dllist: rating %d does not exist", index)
+	}
+
+	rating := l.RatingsTable[index]
+	end := uint64(rating.JPEGOffset) + uint64(rating.JPEGSize)
+	if end > uint64(len(l.raw)) {
+		return nil, fmt.Errorf("%w: rating %d image ends at 0x%X, file is 0x%X bytes", ErrTruncated, index, end, len(l.raw))
+	}
+
+	return l.raw[rating.JPEGOffset:end], nil
+}
+
+// index returns the position in a table of the record at offset.
+func (h *Header) index(offset, tableOffset, count uint32, size int) (int, error) {
+	if offset < tableOffset || (offset-tableOffset)%uint32(size) != 0 {
+		return 0, fmt.Errorf("%w: 0x%X", ErrInvalidOffset, offset)
+	}
+
+	i := (offset - tableOffset) / uint32(size)
+	if i >= count {
+		return 0, fmt.Errorf("%w: 0x%X", ErrInvalidOffset, offset)
+	}
+
+	return int(i), nil
+}
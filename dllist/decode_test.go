@@ -0,0 +1,113 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/wii-tools/lzx/lz10"
+	"hash/crc32"
+	"testing"
+)
+
+func decodeDLList(t *testing.T) *List {
+	t.Helper()
+
+	l, err := DecodeBytes(constants.DLList)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return l
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	l := decodeDLList(t)
+	h := &l.Header
+
+	// The tables of the list are not contiguous, as rating images sit between them, so each
+	// table is re-encoded on its own and compared with the bytes at its offset.
+	tables := []struct {
+		name   string
+		offset uint32
+		table  any
+	}{
+		{"header", 0, l.Header},
+		{"ratings", h.RatingTableOffset, l.RatingsTable},
+		{"title types", h.TitleTypeTableOffset, l.TitleTypesTable},
+		{"companies", h.CompanyTableOffset, l.CompaniesTable},
+		{"titles", h.TitleTableOffset, l.TitleTable},
+		{"new titles", h.NewTitleTableOffset, l.NewTitleTable},
+		{"videos", h.VideoTableOffset, l.VideoTable},
+		{"new videos", h.NewVideoTableOffset, l.NewVideoTable},
+		{"demos", h.DemoTableOffset, l.DemoTable},
+		{"recommendations", h.RecommendationTableOffset, l.RecommendationTable},
+		{"recent recommendations", h.RecentRecommendationTableOffset, l.RecentRecommendationTable},
+		{"popular videos", h.PopularVideoTableOffset, l.PopularVideosTable},
+		{"detailed ratings", h.DetailedRatingTablesOffset, l.DetailedRatingTable},
+	}
+
+	for _, table := range tables {
+		encoded := new(bytes.Buffer)
+		err := binary.Write(encoded, binary.BigEndian, table.table)
+		if err != nil {
+			t.Fatalf("%s: %v", table.name, err)
+		}
+
+		original := constants.DLList[table.offset : int(table.offset)+encoded.Len()]
+		for i := range original {
+			if encoded.Bytes()[i] != original[i] {
+				t.Errorf("%s: re-encoded table differs from the original at 0x%X", table.name, int(table.offset)+i)
+				break
+			}
+		}
+	}
+
+	if int(h.Filesize) != len(constants.DLList) {
+		t.Errorf("Filesize is %d, file is %d bytes", h.Filesize, len(constants.DLList))
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	_, err := DecodeBytes(constants.DLList[:len(constants.DLList)/2])
+	if !errors.Is(err, ErrTruncated) {
+		t.Errorf("decoding a truncated list gave %v, want ErrTruncated", err)
+	}
+
+	compressed, err := lz10.Compress(constants.DLList[:headerSize])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Decode(bytes.NewReader(compressed[:len(compressed)/2]))
+	if !errors.Is(err, ErrTruncated) {
+		t.Errorf("decoding a truncated LZ10 stream gave %v, want ErrTruncated", err)
+	}
+}
+
+func TestDecodeCRC(t *testing.T) {
+	l := decodeDLList(t)
+
+	data := append([]byte{}, constants.DLList...)
+	binary.BigEndian.PutUint32(data[8:12], 0)
+	if checksum := crc32.ChecksumIEEE(data); checksum != l.Header.CRC32 {
+		t.Fatalf("CRC32 is 0x%08X, the list with it zeroed has 0x%08X", l.Header.CRC32, checksum)
+	}
+
+	// Changing any byte after the header must be caught by Verify.
+	data = append([]byte{}, constants.DLList...)
+	data[len(data)-1] ^= 0xFF
+	corrupt, err := DecodeBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+	for _, violation := range Verify(corrupt) {
+		found = found || violation.Table == "Header"
+	}
+
+	if !found {
+		t.Error("Verify did not report the CRC32 of a corrupt list")
+	}
+}
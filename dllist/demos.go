@@ -1,12 +1,5 @@
 package dllist
 
-import (
-	"NintendoChannel/constants"
-	"bytes"
-	"github.com/kaitai-io/kaitai_struct_go_runtime/kaitai"
-	"unicode/utf16"
-)
-
 type DemoTable struct {
 	ID            uint32
 	Title         [31]uint16
@@ -23,38 +16,31 @@ type DemoTable struct {
 	_             [205]byte
 }
 
-func (l *List) MakeDemoTable() {
+func (l *List) MakeDemoTable() error {
 	l.Header.DemoTableOffset = l.GetCurrentSize()
 
-	dl := NewNinchDllist()
-	err := dl.Read(kaitai.NewStream(bytes.NewReader(constants.DLList)), nil, dl)
-	checkError(err)
-
-	demos, err := dl.DemosTable()
-	checkError(err)
-
-	for i, demo := range demos {
-		var title [31]uint16
-		tempTitle := utf16.Encode([]rune(demo.Title))
-		copy(title[:], tempTitle)
-
-		var subtitle [31]uint16
-		tempSubtitle := utf16.Encode([]rune(demo.Subtitle))
-		copy(subtitle[:], tempSubtitle)
+	nintendoList, err := getNintendoList()
+	if err != nil {
+		return err
+	}
 
+	// The demos of the archived list keep the company offsets of the list they were first copied from,
+	// which do not point into its own company table, so every demo is credited to the first company instead.
+	for i, demo := range nintendoList.DemoTable {
 		l.DemoTable = append(l.DemoTable, DemoTable{
 			ID:            uint32(i),
-			Title:         title,
-			Subtitle:      subtitle,
-			TitleID:       demo.Titleid,
+			Title:         demo.Title,
+			Subtitle:      demo.Subtitle,
+			TitleID:       demo.TitleID,
 			CompanyOffset: l.Header.CompanyTableOffset,
 			RemovalYear:   0xFFFF,
 			RemovalMonth:  0xFF,
 			RemovalDay:    0xFF,
-			RatingID:      demo.RatingId,
+			RatingID:      demo.RatingID,
 			IsNew:         0,
 		})
 	}
 
 	l.Header.NumberOfDemoTables = uint32(len(l.DemoTable))
+	return nil
 }
@@ -1,23 +1,24 @@
 package dllist
 
 import (
+	"NintendoChannel/config"
 	"NintendoChannel/constants"
 	"NintendoChannel/gametdb"
 	"NintendoChannel/info"
-	"bufio"
+	"NintendoChannel/layout"
+	"NintendoChannel/store"
 	"bytes"
-	"context"
-	"database/sql"
 	"encoding/binary"
 	"fmt"
-	_ "github.com/go-sql-driver/mysql"
 	"github.com/wii-tools/lzx/lz10"
 	"hash/crc32"
 	"io"
-	"log"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
+	"time"
 )
 
 type List struct {
@@ -40,159 +41,285 @@ type List struct {
 	region      constants.Region
 	ratingGroup constants.RatingGroup
 	language    constants.Language
+	config      *config.Config
 	// map[game_id]amount_voted
 	recommendations map[string]int
-	imageBuffer     *bytes.Buffer
+	store           store.Store
+	companies       *companyResolver
+	// titleIDs holds the ID in TitleTable of every game that will be listed.
+	titleIDs map[*gametdb.Game]uint32
+	// missingLocales holds the title fields that came from a fallback locale.
+	missingLocales []MissingLocale
+	// unmappedRatings holds the titles whose rating could not be converted to the board of the list.
+	unmappedRatings []UnmappedRating
+	titleOverrides  layout.Overrides
+	infoManifest    *infoManifest
+	audiences       map[string]*audienceData
+	firstSeen       gametdb.FirstSeen
+	// now is when generation started, so every list agrees on which titles are new.
+	now time.Time
+	// titleDates holds the date each entry of TitleTable became available.
+	titleDates  []time.Time
+	imageBuffer *bytes.Buffer
+	// raw is the decompressed file a decoded List was read from.
+	raw []byte
+	// unratedTitles counts the titles without a rating, which get constants.UnratedRatingID.
+	unratedTitles int
 }
 
-func checkError(err error) {
+var (
+	nintendoListOnce sync.Once
+	nintendoList     *List
+	nintendoListErr  error
+)
+
+// getNintendoList returns the archived Nintendo dllist.bin, which we source demos and detailed ratings from.
+func getNintendoList() (*List, error) {
+	nintendoListOnce.Do(func() {
+		nintendoList, nintendoListErr = DecodeBytes(constants.DLList)
+		if nintendoListErr != nil {
+			nintendoListErr = fmt.Errorf("decoding embedded Nintendo list: %w", nintendoListErr)
+		}
+	})
+
+	return nintendoList, nintendoListErr
+}
+
+// MakeDownloadList generates a dllist.bin for every region and language in regions,
+// writing them to <OutputDir>/lists/<region>/<language>/dllist.bin.
+//
+// Failing to prepare shared data aborts the run. A title that cannot be generated is skipped,
+// and a list that cannot be generated is reported without stopping the other workers.
+func MakeDownloadList(cfg *config.Config, regions []constants.RegionMeta, overwrite bool) error {
+	// Initialize the store shared by every worker
+	s, err := store.Open(cfg.Database)
 	if err != nil {
-		log.Fatalf("Nintendo Channel file generator has encountered a fatal error! Reason: %v\n", err)
+		return fmt.Errorf("opening store: %w", err)
 	}
-}
+	defer s.Close()
 
-var pool *sql.DB
-var ctx = context.Background()
+	err = gametdb.PrepareGameTDB(cfg.GameTDB)
+	if err != nil {
+		return err
+	}
 
-func MakeDownloadList(overwrite bool) {
-	file, err := os.Open("sql.txt")
+	err = info.GetTimePlayed(s)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	defer file.Close()
 
-	// Read the password from the file
-	scanner := bufio.NewScanner(file)
-	scanner.Scan()
-	password := scanner.Text()
+	info.PrepareCoverArt(cfg.CoverArt)
+
+	err = info.PrepareDescriptorFonts(cfg.DescriptorFonts)
+	if err != nil {
+		return err
+	}
 
-	// Check for errors while scanning
-	if err := scanner.Err(); err != nil {
-		panic(err)
+	titleOverrides, err := layout.LoadOverrides(cfg.TitleOverridesPath)
+	if err != nil {
+		return err
 	}
 
-	// Initialize database
-	pool, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", "rc24", password, "127.0.0.1", 3306, "rc24_nc"))
+	audiences, err := loadAudiences(s)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	// Ensure this Postgresql connection is valid.
-	defer pool.Close()
-	gametdb.PrepareGameTDB()
-	info.GetTimePlayed(ctx, pool)
+	now := time.Now().UTC()
+	firstSeen, err := gametdb.TrackFirstSeen(cfg.NewTitles.FirstSeenPath, now)
+	if err != nil {
+		return fmt.Errorf("tracking first seen titles: %w", err)
+	}
 
 	wg := sync.WaitGroup{}
 	runtime.GOMAXPROCS(runtime.NumCPU())
 	semaphore := make(chan struct{}, 3)
 
-	wg.Add(10)
-	for _, region := range constants.Regions {
+	var failedMutex sync.Mutex
+	var failed []string
+
+	for _, region := range regions {
 		for _, language := range region.Languages {
+			wg.Add(1)
 			go func(_region constants.RegionMeta, _language constants.Language) {
 				defer wg.Done()
 				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
 
-				var reg [3]string
-				reg[0] = "JP"
-				reg[1] = "GB"
-				reg[2] = "US"
-
-				var lang [7]string
-				lang[0] = "ja"
-				lang[1] = "en"
-				lang[2] = "de"
-				lang[3] = "fr"
-				lang[4] = "es"
-				lang[5] = "it"
-				lang[6] = "nl"
-
-				fmt.Printf("Starting worker - Region: %s, Language: %s\n", reg[_region.Region], lang[_language])
+				fmt.Printf("Starting worker - Region: %s, Language: %s\n", _region.Region, _language)
 				list := List{
 					region:          _region.Region,
 					ratingGroup:     _region.RatingGroup,
 					language:        _language,
+					config:          cfg,
+					store:           s,
+					firstSeen:       firstSeen,
+					audiences:       audiences,
+					titleOverrides:  titleOverrides,
+					now:             now,
 					imageBuffer:     new(bytes.Buffer),
 					recommendations: map[string]int{},
 				}
 
-				list.QueryRecommendations()
-
-				list.MakeHeader()
-				list.MakeRatingsTable()
-				list.MakeTitleTypeTable()
-				list.MakeCompaniesTable()
-				list.MakeTitleTable(overwrite)
-				list.MakeNewTitleTable()
-				list.MakeVideoTable()
-				list.MakeNewVideoTable()
-				list.MakeDemoTable()
-				list.MakeRecommendationTable()
-				list.MakeRecentRecommendationTable()
-				list.MakePopularVideoTable()
-				list.MakeDetailedRatingTable()
-				list.WriteRatingImages()
-
-				temp := bytes.NewBuffer(nil)
-				list.WriteAll(temp)
-				list.Header.Filesize = uint32(temp.Len())
-				temp.Reset()
-				list.WriteAll(temp)
-
-				crcTable := crc32.MakeTable(crc32.IEEE)
-				checksum := crc32.Checksum(temp.Bytes(), crcTable)
-				list.Header.CRC32 = checksum
-
-				temp.Reset()
-				list.WriteAll(temp)
-
-				// Compress then write
-				compressed, err := lz10.Compress(temp.Bytes())
-				checkError(err)
-
-				err = os.MkdirAll(fmt.Sprintf("lists/%d/%d/", _region.Region, _language), 0755)
-				checkError(err)
-				err = os.WriteFile(fmt.Sprintf("lists/%d/%d/dllist.bin", _region.Region, _language), compressed, 0666)
-				checkError(err)
-				fmt.Printf("Finished worker - Region: %s, Language: %s\n", reg[_region.Region], lang[_language])
-				<-semaphore
+				err := list.Make(overwrite)
+				if err != nil {
+					fmt.Printf("Failed worker - Region: %s, Language: %s: %v\n", _region.Region, _language, err)
+
+					failedMutex.Lock()
+					failed = append(failed, fmt.Sprintf("%s/%s", _region.Region, _language))
+					failedMutex.Unlock()
+					return
+				}
+
+				fmt.Printf("Finished worker - Region: %s, Language: %s\n", _region.Region, _language)
 			}(region, language)
 		}
 	}
 
 	wg.Wait()
+
+	if len(failed) != 0 {
+		return fmt.Errorf("failed to generate lists for %s", strings.Join(failed, ", "))
+	}
+
+	return nil
 }
 
-// Write writes the current values in Votes to an io.Writer method.
+// Make generates every table of the list, then writes it to disk.
+func (l *List) Make(overwrite bool) error {
+	err := l.QueryRecommendations()
+	if err != nil {
+		return err
+	}
+
+	l.MakeHeader()
+	l.MakeRatingsTable()
+	l.MakeTitleTypeTable()
+
+	err = l.MakeCompaniesTable()
+	if err != nil {
+		return err
+	}
+
+	err = l.MakeTitleTable(overwrite)
+	if err != nil {
+		return err
+	}
+
+	l.MakeNewTitleTable()
+
+	err = l.MakeVideoTable()
+	if err != nil {
+		return err
+	}
+
+	err = l.MakeNewVideoTable()
+	if err != nil {
+		return err
+	}
+
+	err = l.MakeDemoTable()
+	if err != nil {
+		return err
+	}
+
+	l.MakeRecommendationTable()
+	l.MakeRecentRecommendationTable()
+
+	err = l.MakePopularVideoTable()
+	if err != nil {
+		return err
+	}
+
+	err = l.MakeDetailedRatingTable()
+	if err != nil {
+		return err
+	}
+
+	err = l.WriteRatingImages()
+	if err != nil {
+		return err
+	}
+
+	l.Header.Filesize = l.GetCurrentSize()
+
+	temp := bytes.NewBuffer(nil)
+	err = l.WriteAll(temp)
+	if err != nil {
+		return err
+	}
+
+	crcTable := crc32.MakeTable(crc32.IEEE)
+	checksum := crc32.Checksum(temp.Bytes(), crcTable)
+	l.Header.CRC32 = checksum
+
+	temp.Reset()
+	err = l.WriteAll(temp)
+	if err != nil {
+		return err
+	}
+
+	// Compress then write
+	compressed, err := lz10.Compress(temp.Bytes())
+	if err != nil {
+		return fmt.Errorf("compressing list: %w", err)
+	}
+
+	path := ListPath(l.config.OutputDir, l.region, l.language)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, compressed, 0666)
+}
+
+// ListPath returns where the dllist.bin for a region and language is written.
+func ListPath(outputDir string, region constants.Region, language constants.Language) string {
+	return filepath.Join(outputDir, fmt.Sprintf("lists/%d/%d/dllist.bin", region, language))
+}
+
+// tables returns every table in the order they are written to dllist.bin.
+func (l *List) tables() []any {
+	return []any{
+		l.Header,
+		l.RatingsTable,
+		l.TitleTypesTable,
+		l.CompaniesTable,
+		l.TitleTable,
+		l.NewTitleTable,
+		l.VideoTable,
+		l.NewVideoTable,
+		l.DemoTable,
+		l.RecommendationTable,
+		l.RecentRecommendationTable,
+		l.PopularVideosTable,
+		l.DetailedRatingTable,
+	}
+}
+
+// WriteAll writes every table followed by the rating images to an io.Writer.
 // This is required as Go cannot write structs with non-fixed slice sizes,
 // but can write them individually.
-func (l *List) Write(writer io.Writer, data any) {
-	err := binary.Write(writer, binary.BigEndian, data)
-	checkError(err)
-}
+func (l *List) WriteAll(writer io.Writer) error {
+	for _, table := range l.tables() {
+		err := binary.Write(writer, binary.BigEndian, table)
+		if err != nil {
+			return err
+		}
+	}
 
-func (l *List) WriteAll(writer io.Writer) {
-	l.Write(writer, l.Header)
-	l.Write(writer, l.RatingsTable)
-	l.Write(writer, l.TitleTypesTable)
-	l.Write(writer, l.CompaniesTable)
-	l.Write(writer, l.TitleTable)
-	l.Write(writer, l.NewTitleTable)
-	l.Write(writer, l.VideoTable)
-	l.Write(writer, l.NewVideoTable)
-	l.Write(writer, l.DemoTable)
-	l.Write(writer, l.RecommendationTable)
-	l.Write(writer, l.RecentRecommendationTable)
-	l.Write(writer, l.PopularVideosTable)
-	l.Write(writer, l.DetailedRatingTable)
+	_, err := writer.Write(l.imageBuffer.Bytes())
+	return err
 }
 
 // GetCurrentSize returns the current size of our List struct.
 // This is useful for calculating the current offset of List.
 func (l *List) GetCurrentSize() uint32 {
-	buffer := bytes.NewBuffer(nil)
-	l.WriteAll(buffer)
-	buffer.Write(l.imageBuffer.Bytes())
+	size := 0
+	for _, table := range l.tables() {
+		size += binary.Size(table)
+	}
 
-	return uint32(buffer.Len())
+	return uint32(size + l.imageBuffer.Len())
 }
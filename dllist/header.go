@@ -1,6 +1,10 @@
 package dllist
 
 import (
+	"NintendoChannel/thumbnail"
+	"errors"
+	"fmt"
+	"io/fs"
 	"unicode/utf16"
 )
 
@@ -72,8 +76,8 @@ func (l *List) MakeHeader() {
 		Region:                             2,
 		Filesize:                           0,
 		CRC32:                              0,
-		ListID:                             434968891,
-		ThumbnailID:                        1,
+		ListID:                             l.config.ListID,
+		ThumbnailID:                        l.thumbnailID(),
 		CountryCode:                        18,
 		LanguageCode:                       uint32(l.language),
 		UnknownValue:                       [9]byte{1, 0x50, 0x3C, 0xEF, 0, 0, 0, 0, 0},
@@ -107,3 +111,20 @@ func (l *List) MakeHeader() {
 		UnknownValue3:                      285278430,
 	}
 }
+
+// thumbnailID returns the ID of the thumbnail.bin of the list, so that the Wii downloads it whenever it changes.
+// The thumbnail command must run before the list is generated. Otherwise the list gets an ID of 1 that matches
+// no thumbnail.bin, which is warned about here and reported by verify.
+func (l *List) thumbnailID() uint32 {
+	path := thumbnail.Path(l.config.OutputDir, l.region, l.language)
+	id, err := thumbnail.ReadID(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("WARNING: %s does not exist, run thumbnail before dllist. Using thumbnail ID 1, which will not match it - Region: %s, Language: %s\n", path, l.region, l.language)
+		return 1
+	} else if err != nil {
+		fmt.Printf("WARNING: could not read the thumbnail ID, using thumbnail ID 1 - Region: %s, Language: %s: %v\n", l.region, l.language, err)
+		return 1
+	}
+
+	return id
+}
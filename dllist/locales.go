@@ -0,0 +1,109 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/gametdb"
+	"fmt"
+	"path/filepath"
+)
+
+// localization is the text of a game in the language of a list. Each field records
+// the GameTDB locale it was taken from, or an empty string if no locale had it.
+type localization struct {
+	title          string
+	titleLocale    string
+	synopsis       string
+	synopsisLocale string
+}
+
+// MissingLocale is a field of a title that had to be taken from a fallback locale.
+type MissingLocale struct {
+	// Title is the title as "<database>/<game ID>".
+	Title string `json:"title"`
+	Field string `json:"field"`
+	// Wanted is the first locale of the fallback chain, Used is the locale the field came from.
+	Wanted string `json:"wanted"`
+	Used   string `json:"used"`
+}
+
+// getTitleType returns the title type of a game, which is defaultTitleType unless GameTDB lists another.
+func getTitleType(game *gametdb.Game, defaultTitleType constants.TitleType) constants.TitleType {
+	titleType, ok := constants.TitleTypeMap[game.Type]
+	if game.Type == "" || !ok {
+		return defaultTitleType
+	}
+
+	if titleType == constants.NES && defaultTitleType == constants.NintendoThreeDS {
+		return constants.ThreeDSDownload
+	}
+
+	return titleType
+}
+
+// localeChain returns the GameTDB locales the text of the list is taken from, in order.
+func (l *List) localeChain() []string {
+	if chain, ok := l.config.Locales[l.region.String()+"/"+l.language.String()]; ok {
+		return chain
+	}
+
+	return l.config.Locales[l.language.String()]
+}
+
+// localize resolves the title and synopsis of a game independently along the locale chain.
+// A field missing from every locale of the chain is taken from the first locale that has it.
+func (l *List) localize(game *gametdb.Game) localization {
+	var text localization
+	chain := append(append([]string{}, l.localeChain()...), "")
+	for _, locale := range chain {
+		for _, meta := range game.Locale {
+			if locale != "" && meta.Language != locale {
+				continue
+			}
+
+			if text.title == "" && meta.Title != "" {
+				text.title, text.titleLocale = meta.Title, meta.Language
+			}
+
+			if text.synopsis == "" && meta.Synopsis != "" {
+				text.synopsis, text.synopsisLocale = meta.Synopsis, meta.Language
+			}
+		}
+	}
+
+	return text
+}
+
+// reportMissingLocales records every field of a title that did not come from the first locale of the chain.
+func (l *List) reportMissingLocales(title string, text localization) {
+	chain := l.localeChain()
+	if len(chain) == 0 {
+		return
+	}
+
+	for _, field := range []struct{ name, used string }{
+		{"title", text.titleLocale},
+		{"synopsis", text.synopsisLocale},
+	} {
+		if field.used != chain[0] {
+			l.missingLocales = append(l.missingLocales, MissingLocale{title, field.name, chain[0], field.used})
+		}
+	}
+}
+
+// MissingLocalesPath returns where the fields taken from fallback locales in a list are reported.
+func MissingLocalesPath(outputDir string, region constants.Region, language constants.Language) string {
+	return filepath.Join(outputDir, fmt.Sprintf("reports/%d/%d/missing_locales.json", region, language))
+}
+
+func (l *List) writeMissingLocales() error {
+	if len(l.missingLocales) != 0 {
+		fmt.Printf("%d title fields used a fallback locale - Region: %s, Language: %s\n", len(l.missingLocales), l.region, l.language)
+	}
+
+	missing := l.missingLocales
+	if missing == nil {
+		missing = []MissingLocale{}
+	}
+
+	return writeReport(MissingLocalesPath(l.config.OutputDir, l.region, l.language), missing)
+}
@@ -0,0 +1,120 @@
+package dllist
+
+import (
+	"NintendoChannel/config"
+	"NintendoChannel/constants"
+	"NintendoChannel/gametdb"
+	"strings"
+	"testing"
+)
+
+// localizedGame returns a game with a title and synopsis in each locale, and only a title in titleOnly.
+func localizedGame(locales []string, titleOnly ...string) *gametdb.Game {
+	game := &gametdb.Game{ID: "RMCE"}
+	for _, locale := range locales {
+		game.Locale = append(game.Locale, gametdb.GameMeta{Language: locale, Title: "Title " + locale, Synopsis: "Synopsis " + locale})
+	}
+
+	for _, locale := range titleOnly {
+		game.Locale = append(game.Locale, gametdb.GameMeta{Language: locale, Title: "Title " + locale})
+	}
+
+	return game
+}
+
+func TestLocalize(t *testing.T) {
+	cfg := config.Default()
+	// Spanish in the US prefers French to English, which overrides the chain of the language.
+	cfg.Locales["US/es"] = []string{"ES", "FR", "EN"}
+
+	for _, test := range []struct {
+		name string
+		game *gametdb.Game
+		// want returns the locales the title and synopsis are expected from.
+		want func(region constants.Region, language constants.Language) (title, synopsis string)
+	}{
+		{
+			name: "text in every language",
+			game: localizedGame([]string{"JA", "EN", "DE", "FR", "ES", "IT", "NL"}),
+			want: func(region constants.Region, language constants.Language) (string, string) {
+				locale := strings.ToUpper(language.String())
+				return locale, locale
+			},
+		},
+		{
+			name: "no text in the language",
+			game: localizedGame([]string{"EN", "FR"}),
+			want: func(region constants.Region, language constants.Language) (string, string) {
+				switch {
+				case language == constants.French:
+					return "FR", "FR"
+				case region == constants.NTSC && language == constants.Spanish:
+					return "FR", "FR"
+				default:
+					return "EN", "EN"
+				}
+			},
+		},
+		{
+			name: "no text in any locale of the chain",
+			game: localizedGame([]string{"ZHCN"}),
+			want: func(constants.Region, constants.Language) (string, string) {
+				return "ZHCN", "ZHCN"
+			},
+		},
+		{
+			name: "title and synopsis from different locales",
+			game: localizedGame([]string{"EN"}, "DE", "JA"),
+			want: func(region constants.Region, language constants.Language) (string, string) {
+				switch language {
+				case constants.German:
+					return "DE", "EN"
+				case constants.Japanese:
+					return "JA", "EN"
+				default:
+					return "EN", "EN"
+				}
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for _, region := range constants.Regions {
+				for _, language := range region.Languages {
+					l := &List{region: region.Region, language: language, config: cfg}
+					text := l.localize(test.game)
+					title, synopsis := test.want(region.Region, language)
+					if text.titleLocale != title || text.title != "Title "+title {
+						t.Errorf("%s/%s: title %q from %q, want %s", region.Region, language, text.title, text.titleLocale, title)
+					}
+
+					if text.synopsisLocale != synopsis || text.synopsis != "Synopsis "+synopsis {
+						t.Errorf("%s/%s: synopsis %q from %q, want %s", region.Region, language, text.synopsis, text.synopsisLocale, synopsis)
+					}
+
+					l.reportMissingLocales("wiitdb/RMCE", text)
+					wanted := l.localeChain()[0]
+					for _, missing := range l.missingLocales {
+						if missing.Wanted != wanted || missing.Used == wanted {
+							t.Errorf("%s/%s: reported %+v", region.Region, language, missing)
+						}
+					}
+
+					if expected := countMissing(wanted, title, synopsis); len(l.missingLocales) != expected {
+						t.Errorf("%s/%s: reported %d missing fields, want %d", region.Region, language, len(l.missingLocales), expected)
+					}
+				}
+			}
+		})
+	}
+}
+
+func countMissing(wanted string, used ...string) int {
+	missing := 0
+	for _, locale := range used {
+		if locale != wanted {
+			missing++
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,168 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/gametdb"
+	"NintendoChannel/info"
+	"NintendoChannel/layout"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// infoFormatVersion is part of every info input hash. Bump it when the generator changes
+// in a way that requires every info file to be regenerated.
+const infoFormatVersion = 5
+
+// infoInputs is everything an info file is generated from.
+type infoInputs struct {
+	Version    int
+	Game       *gametdb.Game
+	Title      layout.Title
+	Synopsis   string
+	TimePlayed *info.TimePlayed
+	TitleType  constants.TitleType
+	RatingID   uint8
+	CompanyID  uint32
+	InfoListID uint32
+	// CoverArt is the hash of the cover, so replacing a cover regenerates the info file.
+	CoverArt    string
+	Descriptors []info.Descriptor
+}
+
+func (inputs *infoInputs) hash() (string, error) {
+	contents, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// infoManifest tracks the input hash of every info file of a list, so only titles whose
+// inputs changed are regenerated.
+type infoManifest struct {
+	path string
+	// previous holds the hashes of the last run, current those of this one. Both are keyed by file ID.
+	previous map[string]string
+	current  map[string]string
+	added    int
+	changed  int
+	failed   int
+	// kept counts the failed titles whose previous entry was carried forward.
+	kept int
+}
+
+// InfoManifestPath returns where the info input hashes of a list are stored.
+func InfoManifestPath(outputDir string, region constants.Region, language constants.Language) string {
+	return filepath.Join(outputDir, fmt.Sprintf("infos/%d/%d/manifest.json", region, language))
+}
+
+func loadInfoManifest(path string) (*infoManifest, error) {
+	m := &infoManifest{
+		path:     path,
+		previous: map[string]string{},
+		current:  map[string]string{},
+	}
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(contents, &m.previous)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
+// upToDate reports whether the info file at path was generated from inputs with hash.
+func (m *infoManifest) upToDate(fileID uint32, hash, path string) bool {
+	if m.previous[fmt.Sprint(fileID)] != hash {
+		return false
+	}
+
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// record marks the info file as current.
+func (m *infoManifest) record(fileID uint32, hash string, generated bool) {
+	key := fmt.Sprint(fileID)
+	m.current[key] = hash
+	if !generated {
+		return
+	}
+
+	if _, ok := m.previous[key]; ok {
+		m.changed++
+	} else {
+		m.added++
+	}
+}
+
+// fail keeps the previous entry of a title that failed to generate if its info file at path exists,
+// so the title stays listed with its last good info file. The stale hash makes the title be retried
+// on the next run. It returns false if there is no info file to keep, and the title must be skipped.
+func (m *infoManifest) fail(fileID uint32, path string) bool {
+	m.failed++
+	key := fmt.Sprint(fileID)
+	hash, ok := m.previous[key]
+	if !ok {
+		return false
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+
+	m.current[key] = hash
+	m.kept++
+	return true
+}
+
+// finish removes the info files of titles no longer in the list, saves the manifest and
+// returns how many titles were removed.
+func (m *infoManifest) finish(outputDir string, region constants.Region, language constants.Language) (int, error) {
+	removed := 0
+	for key := range m.previous {
+		if _, ok := m.current[key]; ok {
+			continue
+		}
+
+		var fileID uint32
+		_, err := fmt.Sscan(key, &fileID)
+		if err != nil {
+			return removed, fmt.Errorf("%s: invalid file ID %q", m.path, key)
+		}
+
+		err = os.Remove(info.Path(outputDir, region, language, fileID))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	contents, err := json.MarshalIndent(m.current, "", "\t")
+	if err != nil {
+		return removed, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(m.path), 0755)
+	if err != nil {
+		return removed, err
+	}
+
+	return removed, os.WriteFile(m.path, contents, 0666)
+}
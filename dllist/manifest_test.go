@@ -0,0 +1,60 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/info"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInfoManifestFail(t *testing.T) {
+	dir := t.TempDir()
+	path := InfoManifestPath(dir, constants.NTSC, constants.English)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(path, []byte(`{"1": "a", "2": "b"}`), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := loadInfoManifest(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Title 1 has its info file, title 2 lost it and title 3 is new.
+	kept := info.Path(dir, constants.NTSC, constants.English, 1)
+	err = os.WriteFile(kept, []byte("info"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.fail(1, kept) {
+		t.Error("a failed title with an info file was not kept")
+	}
+
+	if m.fail(2, info.Path(dir, constants.NTSC, constants.English, 2)) {
+		t.Error("a failed title without an info file was kept")
+	}
+
+	if m.fail(3, info.Path(dir, constants.NTSC, constants.English, 3)) {
+		t.Error("a failed title that was never generated was kept")
+	}
+
+	_, err = m.finish(dir, constants.NTSC, constants.English)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(kept); err != nil {
+		t.Errorf("the info file of a kept title was removed: %v", err)
+	}
+
+	if m.current["1"] != "a" || len(m.current) != 1 {
+		t.Errorf("manifest is %v, want only the previous entry of title 1", m.current)
+	}
+}
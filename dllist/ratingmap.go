@@ -0,0 +1,170 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/gametdb"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// errRatingPending is returned for titles that are waiting on a rating.
+	errRatingPending = errors.New("rating pending")
+	// errNoRating is returned for titles GameTDB has no rating for.
+	errNoRating = errors.New("no rating")
+)
+
+// ratingBoardGroups maps the rating boards that have a RatingsTable to their RatingGroup.
+var ratingBoardGroups = map[string]constants.RatingGroup{
+	"CERO": constants.CERO,
+	"ESRB": constants.ESRB,
+	"PEGI": constants.PEGI,
+}
+
+// ratingAges is the minimum age of every rating GameTDB lists, for every board.
+// They are used to translate a rating to the board of a list.
+var ratingAges = map[string]map[string]uint8{
+	"CERO": {"A": 0, "B": 12, "C": 15, "D": 17, "Z": 18},
+	"ESRB": {"3": 3, "EC": 3, "E": 6, "E10+": 10, "T": 13, "M": 17, "AO": 18},
+	"PEGI": {"3": 3, "4": 4, "6": 6, "7": 7, "12": 12, "15": 15, "16": 16, "18": 18},
+	"USK":  {"0": 0, "6": 6, "12": 12, "16": 16, "18": 18},
+	"OFLC": {"G": 0, "G8+": 8, "PG": 8, "M": 15, "M15+": 15, "MA15+": 15, "R18+": 18},
+	"GRB":  {"ALL": 0, "12": 12, "15": 15, "18": 18},
+}
+
+// ratingBoardAliases are other names GameTDB uses for a board.
+var ratingBoardAliases = map[string]string{
+	"ACB": "OFLC",
+}
+
+// gameTDBRatingToRatingID maps the ratings of the boards that have a RatingsTable to their ID in it.
+var gameTDBRatingToRatingID = map[string]map[string]uint8{
+	"CERO": {
+		"A": 8,
+		"B": 9,
+		"C": 10,
+		"D": 11,
+		"Z": 12,
+	},
+	"ESRB": {
+		// For some reason GameTDB has EC as 3 for some titles
+		"3":    8,
+		"EC":   8,
+		"E":    9,
+		"E10+": 10,
+		"T":    11,
+		"M":    12,
+	},
+	// The Portuguese and Finnish ratings 4, 6 and 15 are translated by age instead.
+	"PEGI": {
+		"3":  8,
+		"7":  9,
+		"12": 10,
+		"16": 11,
+		"18": 12,
+	},
+}
+
+// UnmappedRating is a title whose rating could not be converted to the board of a list.
+type UnmappedRating struct {
+	// Title is the title as "<database>/<game ID>".
+	Title  string `json:"title"`
+	Board  string `json:"board"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// GetRatingID returns the ID in the RatingsTable of group of a rating from GameTDB.
+// A rating from another board is translated to the lowest rating of group with at least the same age.
+func GetRatingID(rating gametdb.Rating, group constants.RatingGroup) (uint8, error) {
+	board := strings.ToUpper(strings.TrimSpace(rating.Type))
+	if alias, ok := ratingBoardAliases[board]; ok {
+		board = alias
+	}
+
+	value := strings.ToUpper(strings.TrimSpace(rating.Value))
+	if value == "RP" {
+		return 0, errRatingPending
+	} else if board == "" || value == "" {
+		return 0, errNoRating
+	}
+
+	if ratingBoardGroups[board] == group {
+		if id, ok := gameTDBRatingToRatingID[board][value]; ok {
+			return id, nil
+		}
+	}
+
+	age, ok := ratingAges[board][value]
+	if !ok {
+		return 0, fmt.Errorf("unknown %s rating %q", board, value)
+	}
+
+	ratings := constants.RatingsData[group]
+	for i, data := range ratings {
+		if data.Age >= age {
+			return constants.FirstRatingID + uint8(i), nil
+		}
+	}
+
+	return constants.FirstRatingID + uint8(len(ratings)-1), nil
+}
+
+// pendingRatingID returns the rating shown for titles that are not rated yet.
+// Only ESRB has a mark for this, other boards show no rating.
+func (l *List) pendingRatingID() uint8 {
+	if l.ratingGroup == constants.ESRB {
+		return constants.RatingPendingID
+	}
+
+	return constants.NoRatingID
+}
+
+// ratingID returns the rating of a game in the list, recording ratings that could not be mapped.
+// Unrated titles get constants.UnratedRatingID and titles waiting on a rating get pendingRatingID.
+// Neither is reported, only ratings from a board or with a value that could not be translated are.
+func (l *List) ratingID(title string, game *gametdb.Game) uint8 {
+	id, err := GetRatingID(game.Rating, l.ratingGroup)
+	switch {
+	case err == nil:
+		return id
+	case errors.Is(err, errNoRating):
+		l.unratedTitles++
+		return constants.UnratedRatingID
+	case errors.Is(err, errRatingPending):
+		return l.pendingRatingID()
+	}
+
+	l.unmappedRatings = append(l.unmappedRatings, UnmappedRating{
+		Title:  title,
+		Board:  game.Rating.Type,
+		Value:  game.Rating.Value,
+		Reason: err.Error(),
+	})
+
+	return l.pendingRatingID()
+}
+
+// UnmappedRatingsPath returns where the titles whose rating could not be mapped in a list are reported.
+func UnmappedRatingsPath(outputDir string, region constants.Region, language constants.Language) string {
+	return filepath.Join(outputDir, fmt.Sprintf("reports/%d/%d/unmapped_ratings.json", region, language))
+}
+
+func (l *List) writeUnmappedRatings() error {
+	if len(l.unmappedRatings) != 0 {
+		fmt.Printf("%d titles have a rating that could not be mapped - Region: %s, Language: %s\n", len(l.unmappedRatings), l.region, l.language)
+	}
+
+	if l.unratedTitles != 0 {
+		fmt.Printf("%d titles are unrated and use the default rating - Region: %s, Language: %s\n", l.unratedTitles, l.region, l.language)
+	}
+
+	unmapped := l.unmappedRatings
+	if unmapped == nil {
+		unmapped = []UnmappedRating{}
+	}
+
+	return writeReport(UnmappedRatingsPath(l.config.OutputDir, l.region, l.language), unmapped)
+}
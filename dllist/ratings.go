@@ -2,9 +2,7 @@ package dllist
 
 import (
 	"NintendoChannel/constants"
-	"bytes"
-	"github.com/kaitai-io/kaitai_struct_go_runtime/kaitai"
-	"unicode/utf16"
+	"errors"
 )
 
 // RatingTable contains the data for the game ratings.
@@ -42,42 +40,63 @@ func (l *List) MakeRatingsTable() {
 		l.RatingsTable = append(l.RatingsTable, ratingTable)
 	}
 
+	if l.ratingGroup == constants.ESRB {
+		l.RatingsTable = append(l.RatingsTable, RatingTable{
+			RatingID:    constants.RatingPendingID,
+			RatingGroup: l.ratingGroup,
+			Age:         6,
+			Unknown:     222,
+			RatingTitle: [11]uint16{'R', 'P'},
+		})
+	}
+
+	// Titles without a rating point to an entry without an image, as Nintendo's lists do.
+	l.RatingsTable = append(l.RatingsTable, RatingTable{
+		RatingID:    constants.NoRatingID,
+		RatingGroup: l.ratingGroup,
+		Unknown:     222,
+	})
+
 	l.Header.NumberOfRatingTables = uint32(len(l.RatingsTable))
 }
 
-func (l *List) MakeDetailedRatingTable() {
+func (l *List) MakeDetailedRatingTable() error {
 	l.Header.DetailedRatingTablesOffset = l.GetCurrentSize()
 
-	// TODO: Move away from kaitai
-	dl := NewNinchDllist()
-	err := dl.Read(kaitai.NewStream(bytes.NewReader(constants.DLList)), nil, dl)
-	checkError(err)
-
-	detailedRatings, err := dl.DetailedRatingsTable()
-	checkError(err)
-
-	for _, table := range detailedRatings {
-		var title [102]uint16
-		tempTitle := utf16.Encode([]rune(table.Title))
-		copy(title[:], tempTitle)
-
-		l.DetailedRatingTable = append(l.DetailedRatingTable, DetailedRatingTable{
-			RatingGroup: constants.RatingGroup(table.RatingGroup),
-			RatingID:    table.RatingId,
-			Title:       title,
-		})
+	nintendoList, err := getNintendoList()
+	if err != nil {
+		return err
 	}
 
+	l.DetailedRatingTable = append(l.DetailedRatingTable, nintendoList.DetailedRatingTable...)
+
 	l.Header.NumberOfDetailedRatingTables = uint32(len(l.DetailedRatingTable))
+	return nil
 }
 
-func (l *List) WriteRatingImages() {
+// WriteRatingImages writes the image of every rating in RatingsTable.
+// The rating pending image is taken from Nintendo's list.
+func (l *List) WriteRatingImages() error {
 	deadBeef := []byte{0xDE, 0xAD, 0xBE, 0xEF}
 
-	for i := range l.RatingsTable {
+	for i, rating := range l.RatingsTable {
+		var picture []byte
+		switch rating.RatingID {
+		case constants.NoRatingID:
+			continue
+		case constants.RatingPendingID:
+			var err error
+			picture, err = ratingPendingImage()
+			if err != nil {
+				return err
+			}
+		default:
+			picture = constants.Images[l.ratingGroup][rating.RatingID-constants.FirstRatingID]
+		}
+
 		l.RatingsTable[i].JPEGOffset = l.GetCurrentSize()
-		l.RatingsTable[i].JPEGSize = uint32(len(constants.Images[l.ratingGroup][i]))
-		l.imageBuffer.Write(constants.Images[l.ratingGroup][i])
+		l.RatingsTable[i].JPEGSize = uint32(len(picture))
+		l.imageBuffer.Write(picture)
 
 		counter := 0
 		for l.GetCurrentSize()%32 != 0 {
@@ -85,4 +104,21 @@ func (l *List) WriteRatingImages() {
 			counter++
 		}
 	}
+
+	return nil
+}
+
+func ratingPendingImage() ([]byte, error) {
+	nintendoList, err := getNintendoList()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, rating := range nintendoList.RatingsTable {
+		if rating.RatingID == constants.RatingPendingID {
+			return nintendoList.RatingImage(i)
+		}
+	}
+
+	return nil, errors.New("Nintendo's list has no rating pending image")
 }
@@ -2,10 +2,7 @@ package dllist
 
 import (
 	"NintendoChannel/constants"
-	"bufio"
-	"database/sql"
 	"fmt"
-	"os"
 )
 
 type RecentRecommendationTable struct {
@@ -14,70 +11,14 @@ type RecentRecommendationTable struct {
 	Unknown     uint8
 }
 
-const QueryRecommendations = `SELECT COUNT(game_id), game_id FROM recommendations GROUP BY game_id`
-
-func (l *List) QueryRecommendations() {
-	file, err := os.Open("sql.txt")
+func (l *List) QueryRecommendations() error {
+	recommendations, err := l.store.Recommendations(l.region)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("querying recommendations: %w", err)
 	}
-	defer file.Close()
-
-	// Read the password from the file
-	scanner := bufio.NewScanner(file)
-	scanner.Scan()
-	password := scanner.Text()
-
-	// Check for errors while scanning
-	if err := scanner.Err(); err != nil {
-		panic(err)
-	}
-
-	pool, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", "rc24", password, "127.0.0.1", 3306, "rc24_nc"))
-	if err != nil {
-		panic(err)
-	}
-
-	rows, err := pool.Query(QueryRecommendations)
-	checkError(err)
-
-	for rows.Next() {
-		var gameID string
-		var count int
-		err = rows.Scan(&count, &gameID)
-		checkError(err)
 
-		// First see if this game could exist in all regions
-		isForRegion := false
-		if gameID[3:] == "A" || gameID[3:] == "B" || gameID[3:] == "U" || gameID[3:] == "X" {
-			isForRegion = true
-		} else {
-			// Now determine if the game exists for this region
-			switch l.region {
-			case constants.NTSC:
-				if gameID[3:] == "E" || gameID[3:] == "N" {
-					isForRegion = true
-				}
-				break
-			case constants.Japan:
-				if gameID[3:] == "J" {
-					isForRegion = true
-				}
-				break
-			case constants.PAL:
-				if gameID[3:] == "P" || gameID[3:] == "L" || gameID[3:] == "M" {
-					isForRegion = true
-				}
-				break
-			}
-		}
-
-		if !isForRegion {
-			continue
-		}
-
-		l.recommendations[gameID] = count
-	}
+	l.recommendations = recommendations
+	return nil
 }
 
 func (l *List) MakeRecommendationTable() {
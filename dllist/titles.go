@@ -4,15 +4,12 @@ import (
 	"NintendoChannel/constants"
 	"NintendoChannel/gametdb"
 	"NintendoChannel/info"
-	"encoding/binary"
-	"encoding/hex"
+	"NintendoChannel/layout"
 	"fmt"
-	"github.com/mitchellh/go-wordwrap"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
-	"unicode/utf16"
+	"time"
 )
 
 // CompanyTable represents a company in the dllist.bin
@@ -33,54 +30,57 @@ type TitleTable struct {
 	ReleaseDay    uint8
 	RatingID      uint8
 	Unknown       [2]byte
-	// TODO: Giant bitfield, extremely low priority however implement later.
-	HardcoreBitField uint32
-	FriendsBitField  uint32
-	Unknown3BitField uint32
-	Unknown4         uint16
-	Unknown5         uint16
-	Unknown6         uint8
-	Unknown7         uint32
-	Unknown8         uint32
-	MedalType        constants.Medal
-	Unknown9         uint8
-	TitleName        [31]uint16
-	Subtitle         [31]uint16
-	ShortTitle       [31]uint16
+	// HardcoreBitField and FriendsBitField are who plays the title, see Audience.
+	HardcoreBitField HardcoreBitField
+	FriendsBitField  FriendsBitField
+	Unknown3         [7]byte
+	Unknown4         uint8
+	Flags            TitleFlags
+	Unknown5         [7]byte
+	// MedalType only uses the lower 3 bits.
+	MedalType  constants.Medal
+	Unknown6   [2]byte
+	TitleName  layout.Field
+	Subtitle   layout.Field
+	ShortTitle layout.Field
 }
 
-func (l *List) MakeCompaniesTable() {
+// newTitleTable returns a TitleTable with the audience and flags of a title,
+// and the bytes of unknown purpose that every title of Nintendo's lists has.
+func newTitleTable(audience Audience, flags TitleFlags) TitleTable {
+	return TitleTable{
+		Unknown:          [2]byte{0x8, 0x20},
+		HardcoreBitField: audience.HardcoreBitField(),
+		FriendsBitField:  audience.FriendsBitField(),
+		Unknown3:         [7]byte{0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0xAA, 0x00},
+		Unknown4:         0xAA,
+		Flags:            flags,
+		Unknown5:         [7]byte{0x03},
+		Unknown6:         [2]byte{0x00, 0xDE},
+	}
+}
+
+func (l *List) MakeCompaniesTable() error {
 	l.Header.CompanyTableOffset = l.GetCurrentSize()
 
 	// Only the Wii XML contains company data
-	for _, company := range gametdb.WiiTDB.Companies.Companies {
-		companyID, err := strconv.ParseUint(hex.EncodeToString([]byte(company.Code)), 16, 32)
-		checkError(err)
-
-		var finalDeveloperName [31]uint16
-		developerName := utf16.Encode([]rune(company.Name))
-		copy(finalDeveloperName[:], developerName)
-
-		table := CompanyTable{
-			CompanyID:     uint32(companyID),
-			DeveloperName: finalDeveloperName,
-			PublisherName: finalDeveloperName,
-		}
+	l.companies = newCompanyResolver(gametdb.WiiTDB.Companies.Companies)
+	for _, tdb := range []*gametdb.GameTDB{gametdb.WiiTDB, gametdb.DSTDB, gametdb.ThreeDSTDB} {
+		for i := range tdb.Games {
+			game := &tdb.Games[i]
+			if !l.isForRegion(game) {
+				continue
+			}
 
-		l.CompaniesTable = append(l.CompaniesTable, table)
+			if key, entry, ok := l.companies.entry(game); ok {
+				l.companies.add(key, entry)
+			}
+		}
 	}
 
+	l.CompaniesTable = l.companies.table
 	l.Header.NumberOfCompanyTables = uint32(len(l.CompaniesTable))
-}
-
-var langaugeToLocale = map[constants.Language]string{
-	constants.Japanese: "JA",
-	constants.English:  "EN",
-	constants.German:   "DE",
-	constants.French:   "FR",
-	constants.Spanish:  "ES",
-	constants.Italian:  "IT",
-	constants.Dutch:    "NL",
+	return nil
 }
 
 var regionToGameTDB = map[constants.Region]string{
@@ -89,38 +89,19 @@ var regionToGameTDB = map[constants.Region]string{
 	constants.Japan: "NTSC-J",
 }
 
-var gameTDBRatingToRatingID = map[string]map[string]uint8{
-	"CERO": {
-		"A": 8,
-		"B": 9,
-		"C": 10,
-		"D": 11,
-		"Z": 12,
-	},
-	"ESRB": {
-		// For some reason GameTDB has EC as 3 for some titles
-		"3":    8,
-		"EC":   8,
-		"E":    9,
-		"E10+": 10,
-		"T":    11,
-		"M":    12,
-	},
-	"PEGI": {
-		"3":  8,
-		"4":  8,
-		"6":  9,
-		"7":  9,
-		"12": 10,
-		"15": 11,
-		"16": 11,
-		"18": 12,
-	},
-}
-
-func (l *List) MakeTitleTable(overwrite bool) {
+func (l *List) MakeTitleTable(overwrite bool) error {
 	l.Header.TitleTableOffset = l.GetCurrentSize()
 
+	err := l.resolveTitleIDs()
+	if err != nil {
+		return err
+	}
+
+	l.infoManifest, err = loadInfoManifest(InfoManifestPath(l.config.OutputDir, l.region, l.language))
+	if err != nil {
+		return err
+	}
+
 	// Wii
 	l.GenerateTitleStruct(&gametdb.WiiTDB.Games, constants.Wii, overwrite)
 	// DS
@@ -129,57 +110,49 @@ func (l *List) MakeTitleTable(overwrite bool) {
 	l.GenerateTitleStruct(&gametdb.ThreeDSTDB.Games, constants.NintendoThreeDS, overwrite)
 
 	l.Header.NumberOfTitleTables = uint32(len(l.TitleTable))
-}
+	if l.Header.NumberOfTitleTables == 0 {
+		return fmt.Errorf("no titles could be generated")
+	}
 
-func (l *List) GenerateTitleStruct(games *[]gametdb.Game, defaultTitleType constants.TitleType, overwrite bool) {
-	customSort := func(i, j int) bool {
-		return (*games)[i].Locale[0].Title < (*games)[j].Locale[0].Title
+	removed, err := l.infoManifest.finish(l.config.OutputDir, l.region, l.language)
+	if err != nil {
+		return err
 	}
 
-	sort.Slice(*games, customSort)
-
-	for _, game := range *games {
-		if game.Region == regionToGameTDB[l.region] || game.Region == "ALL" {
-			titleType := defaultTitleType
-			// (Sketch) The first locale will always be English from what I have observed
-			title := game.Locale[0].Title
-			fullTitle := game.Locale[0].Title
-			synopsis := game.Locale[0].Synopsis
-			for _, locale := range game.Locale {
-				if locale.Language == langaugeToLocale[l.language] {
-					if game.Type != "" {
-						title = locale.Title
-						fullTitle = locale.Title
-						synopsis = locale.Synopsis
-						titleType = constants.TitleTypeMap[game.Type]
-
-						if titleType == constants.NES && defaultTitleType == constants.NintendoThreeDS {
-							titleType = constants.ThreeDSDownload
-						}
-					}
-				}
-			}
+	m := l.infoManifest
+	fmt.Printf("Info files - Region: %s, Language: %s: %d added, %d changed, %d removed, %d failed, %d unchanged\n",
+		l.region, l.language, m.added, m.changed, removed, m.failed, len(m.current)-m.added-m.changed-m.kept)
 
-			if titleType == constants.ThreeDSDownload {
+	err = l.writeMissingLocales()
+	if err != nil {
+		return err
+	}
+
+	return l.writeUnmappedRatings()
+}
+
+// GenerateTitleStruct adds every game for this region to the title table.
+// A game that fails to generate is logged and skipped.
+func (l *List) GenerateTitleStruct(games *[]gametdb.Game, defaultTitleType constants.TitleType, overwrite bool) {
+	for i, game := range *games {
+		if l.isForRegion(&game) {
+			titleType := getTitleType(&game, defaultTitleType)
+			if !includesTitle(&game, titleType) {
 				continue
 			}
 
-			// We will not include mods or GameCube games
-			if game.Type == "CUSTOM" || game.Type == "GameCube" || game.Type == "Homebrew" {
+			// Titles that lost a collision with another have no ID.
+			id, ok := l.titleIDs[&(*games)[i]]
+			if !ok {
 				continue
 			}
 
+			text := l.localize(&game)
+			l.reportMissingLocales(titleDatabases[defaultTitleType]+"/"+game.ID, text)
+			fullTitle, synopsis := text.title, text.synopsis
 			var titleID [4]byte
 			copy(titleID[:], game.ID)
 
-			// Wii, DS and 3DS games may share the same IDs are one another. XOR to avoid conflict.
-			id := binary.BigEndian.Uint32(titleID[:])
-			if defaultTitleType == constants.NintendoDS {
-				id ^= 0x22222222
-			} else if defaultTitleType == constants.NintendoThreeDS {
-				id ^= 0x33333333
-			}
-
 			var releaseYear uint16 = 0xFFFF
 			if game.ReleaseDate.Year != "" {
 				temp, _ := strconv.ParseUint(game.ReleaseDate.Year, 10, 32)
@@ -200,30 +173,7 @@ func (l *List) GenerateTitleStruct(games *[]gametdb.Game, defaultTitleType const
 				releaseDay = uint8(temp)
 			}
 
-			subtitle := ""
-			if len(title) > 30 {
-				wrappedTitle := wordwrap.WrapString(title, 30)
-				for i, s := range strings.Split(wrappedTitle, "\n") {
-					switch i {
-					case 0:
-						title = s
-						break
-					case 1:
-						subtitle = s
-						break
-					default:
-						break
-					}
-				}
-			}
-
-			var byteTitle [31]uint16
-			tempTitle := utf16.Encode([]rune(title))
-			copy(byteTitle[:], tempTitle)
-
-			var byteSubtitle [31]uint16
-			tempSubtitle := utf16.Encode([]rune(subtitle))
-			copy(byteSubtitle[:], tempSubtitle)
+			titleLayout := layout.Make(fullTitle, l.titleOverrides.Get(game.ID, l.language))
 
 			medal := constants.None
 			if num, ok := l.recommendations[game.ID[:4]]; ok {
@@ -231,88 +181,121 @@ func (l *List) GenerateTitleStruct(games *[]gametdb.Game, defaultTitleType const
 			}
 
 			companyOffset, companyID := l.GetCompany(&game)
-			table := TitleTable{
-				ID:               id,
-				TitleID:          titleID,
-				TitleType:        titleType,
-				Genre:            l.SetGenre(&game),
-				CompanyOffset:    companyOffset,
-				ReleaseYear:      releaseYear,
-				ReleaseMonth:     releaseMonth,
-				ReleaseDay:       releaseDay,
-				RatingID:         GetRatingID(game.Rating),
-				Unknown:          [2]byte{0x8, 0x20},
-				HardcoreBitField: 536872960,
-				FriendsBitField:  2863311530,
-				Unknown3BitField: 2863311530,
-				Unknown4:         43690,
-				Unknown5:         170,
-				Unknown6:         168,
-				Unknown7:         50331648,
-				Unknown8:         0,
-				MedalType:        medal,
-				Unknown9:         222,
-				TitleName:        byteTitle,
-				Subtitle:         byteSubtitle,
-				ShortTitle:       [31]uint16{},
+
+			table := newTitleTable(l.MakeAudience(&game), MakeTitleFlags(&game))
+			table.ID = id
+			table.TitleID = titleID
+			table.TitleType = titleType
+			table.Genre = l.SetGenre(&game)
+			table.CompanyOffset = companyOffset
+			table.ReleaseYear = releaseYear
+			table.ReleaseMonth = releaseMonth
+			table.ReleaseDay = releaseDay
+			table.RatingID = l.ratingID(titleDatabases[defaultTitleType]+"/"+game.ID, &game)
+			table.MedalType = medal
+			table.TitleName = titleLayout.Title
+			table.Subtitle = titleLayout.Subtitle
+			table.ShortTitle = titleLayout.ShortTitle
+
+			descriptors, unknown := info.NormalizeDescriptors(game.Rating)
+			for _, descriptor := range unknown {
+				fmt.Printf("Unknown %s descriptor %q for title %s\n", game.Rating.Type, descriptor, game.ID)
 			}
 
-			l.TitleTable = append(l.TitleTable, table)
+			inputs := infoInputs{
+				Version:     infoFormatVersion,
+				Game:        &game,
+				Title:       titleLayout,
+				Synopsis:    synopsis,
+				TitleType:   table.TitleType,
+				RatingID:    table.RatingID,
+				CompanyID:   companyID,
+				InfoListID:  l.config.InfoListID,
+				CoverArt:    info.CoverArtHash(defaultTitleType, l.region, game.ID),
+				Descriptors: descriptors,
+			}
+
+			if played, ok := info.LookupTimePlayed(game.ID); ok {
+				inputs.TimePlayed = &played
+			}
 
-			if _, err := os.Stat(fmt.Sprintf("./infos/%d/%d/%d.info", l.region, l.language, binary.BigEndian.Uint32(titleID[:]))); err == nil || !overwrite {
-				// The info file exists, continue on to the next
+			infoPath := info.Path(l.config.OutputDir, l.region, l.language, id)
+			hash, err := inputs.hash()
+			if err != nil {
+				l.failTitle(table, &game, defaultTitleType, infoPath, err)
 				continue
 			}
 
-			var descriptorArray [7]string
-			copy(descriptorArray[:], game.Rating.Descriptor)
+			if !overwrite && l.infoManifest.upToDate(id, hash, infoPath) {
+				// The info file is up to date, continue on to the next
+				l.infoManifest.record(id, hash, false)
+				l.addTitle(table, l.titleDate(&game, defaultTitleType))
+				continue
+			}
 
 			// Write all our static data first
 			i := info.Info{}
 			i.MakeHeader(titleID, game.Controllers.Players, companyID, table.TitleType, table.ReleaseYear, table.ReleaseMonth, table.ReleaseDay)
+			i.Header.DLListID = l.config.InfoListID
 			i.RatingID = table.RatingID
-			i.MakeInfo(id, &game, fullTitle, synopsis, l.region, l.language, defaultTitleType, descriptorArray)
+			err = i.MakeInfo(id, &game, titleLayout, synopsis, l.region, l.language, defaultTitleType, descriptors, l.config.OutputDir)
+			if err != nil {
+				l.failTitle(table, &game, defaultTitleType, infoPath, err)
+				continue
+			}
+
+			l.infoManifest.record(id, hash, true)
+			l.addTitle(table, l.titleDate(&game, defaultTitleType))
 		}
 	}
 }
 
-func GetRatingID(rating gametdb.Rating) uint8 {
-	if rating.Value == "" {
-		// Default to E/7/B
-		return 9
+// failTitle handles a title whose info file could not be generated. If the title has an info file
+// from an earlier run, it stays listed with that file. Otherwise it is skipped, so the list never
+// points to an info file that does not exist.
+func (l *List) failTitle(table TitleTable, game *gametdb.Game, defaultTitleType constants.TitleType, infoPath string, err error) {
+	if !l.infoManifest.fail(table.ID, infoPath) {
+		l.skipTitle(game.ID, err)
+		return
 	}
 
-	return gameTDBRatingToRatingID[rating.Type][rating.Value]
+	fmt.Printf("Keeping the previous info file of title %s - Region: %s, Language: %s: %v\n", game.ID, l.region, l.language, err)
+	l.addTitle(table, l.titleDate(game, defaultTitleType))
 }
 
-func (l *List) GetCompany(game *gametdb.Game) (uint32, uint32) {
-	isDiscGame := false
-	companyID := ""
-	// This first method of retrieving the company is the most accurate. However, it only works with disc games.
-	if len(game.ID) != 4 {
-		isDiscGame = true
-		companyID = game.ID[4:]
+// skipTitle logs a title that will not be included in the list.
+func (l *List) skipTitle(gameID string, err error) {
+	fmt.Printf("Skipping title %s - Region: %s, Language: %s: %v\n", gameID, l.region, l.language, err)
+}
+
+// includesTitle reports whether a game of titleType belongs in the list at all.
+func includesTitle(game *gametdb.Game, titleType constants.TitleType) bool {
+	if titleType == constants.ThreeDSDownload {
+		return false
 	}
 
-	for i, company := range gametdb.WiiTDB.Companies.Companies {
-		if isDiscGame {
-			if companyID == company.Code {
-				intCompanyID, err := strconv.ParseUint(hex.EncodeToString([]byte(company.Code)), 16, 32)
-				checkError(err)
-				return l.Header.CompanyTableOffset + (128 * uint32(i)), uint32(intCompanyID)
-			}
-		} else {
-			if strings.Contains(game.Publisher, company.Name) {
-				intcompanyID, err := strconv.ParseUint(hex.EncodeToString([]byte(company.Code)), 16, 32)
-				checkError(err)
+	// We will not include mods or GameCube games
+	return game.Type != "CUSTOM" && game.Type != "GameCube" && game.Type != "Homebrew"
+}
 
-				return l.Header.CompanyTableOffset + (128 * uint32(i)), uint32(intcompanyID)
-			}
-		}
+// isForRegion reports whether GameTDB lists game as released in the region of the list.
+func (l *List) isForRegion(game *gametdb.Game) bool {
+	return game.Region == regionToGameTDB[l.region] || game.Region == "ALL"
+}
+
+// GetCompany returns the offset of the CompanyTable entry of a game and its company ID.
+func (l *List) GetCompany(game *gametdb.Game) (uint32, uint32) {
+	index := 0
+	key, _, ok := l.companies.entry(game)
+	if ok {
+		index, ok = l.companies.entries[key]
 	}
 
-	// If all fails, default to Nintendo
-	return l.Header.CompanyTableOffset, 12337
+	if !ok {
+		fmt.Printf("No company for title %s - Region: %s, Language: %s, defaulting to Nintendo\n", game.ID, l.region, l.language)
+	}
+
+	return l.Header.CompanyTableOffset + uint32(companyTableSize*index), l.companies.table[index].CompanyID
 }
 
 func (l *List) SetGenre(game *gametdb.Game) [3]byte {
@@ -363,11 +346,60 @@ func (l *List) SetGenre(game *gametdb.Game) [3]byte {
 	return genre
 }
 
+// titleDatabases maps the title type of each GameTDB database to its name.
+var titleDatabases = map[constants.TitleType]string{
+	constants.Wii:             "wiitdb",
+	constants.NintendoDS:      "dstdb",
+	constants.NintendoThreeDS: "3dstdb",
+}
+
+// titleDate returns when a game became available: its release date, or when it first appeared
+// in GameTDB if it has no full release date.
+func (l *List) titleDate(game *gametdb.Game, defaultTitleType constants.TitleType) time.Time {
+	if date, ok := game.ReleaseDate.Time(); ok {
+		return date
+	}
+
+	return l.firstSeen.Get(titleDatabases[defaultTitleType], game.ID)
+}
+
+func (l *List) addTitle(table TitleTable, date time.Time) {
+	l.TitleTable = append(l.TitleTable, table)
+	l.titleDates = append(l.titleDates, date)
+}
+
+// MakeNewTitleTable points to the titles that became available within the configured window, newest first.
 func (l *List) MakeNewTitleTable() {
-	// TODO: Figure out a way to get the newest titles
 	l.Header.NewTitleTableOffset = l.GetCurrentSize()
-	l.NewTitleTable = append(l.NewTitleTable, l.Header.TitleTableOffset)
-	l.Header.NumberOfNewTitleTables = 0
+
+	window := time.Duration(l.config.NewTitles.WindowDays) * 24 * time.Hour
+	var newTitles []int
+	for i, date := range l.titleDates {
+		// Titles with no known date and titles that are not out yet are never new.
+		if date.IsZero() || date.After(l.now) || l.now.Sub(date) > window {
+			continue
+		}
+
+		newTitles = append(newTitles, i)
+	}
+
+	sort.SliceStable(newTitles, func(i, j int) bool {
+		return l.titleDates[newTitles[i]].After(l.titleDates[newTitles[j]])
+	})
+
+	if l.config.NewTitles.Max >= 0 && len(newTitles) > l.config.NewTitles.Max {
+		newTitles = newTitles[:l.config.NewTitles.Max]
+	}
+
+	for _, i := range newTitles {
+		l.NewTitleTable = append(l.NewTitleTable, l.Header.TitleTableOffset+uint32(titleTableSize*i))
+	}
+
+	l.Header.NumberOfNewTitleTables = uint32(len(l.NewTitleTable))
+	if len(l.NewTitleTable) == 0 {
+		// The table is never empty, an unused pointer to the first title is written instead.
+		l.NewTitleTable = append(l.NewTitleTable, l.Header.TitleTableOffset)
+	}
 }
 
 func GetMedal(numberOfTimesVotes int) constants.Medal {
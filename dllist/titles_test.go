@@ -0,0 +1,46 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestNewTitleTableMatchesDLList(t *testing.T) {
+	list, err := Decode(bytes.NewReader(constants.DLList))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range list.TitleTable {
+		flags := NewTitleFlags(want.Flags.Online(), want.Flags.Video(), want.Flags.Multiplayer())
+		if flags != want.Flags {
+			t.Fatalf("title %X: flags are %02X, NewTitleFlags gives %02X", want.ID, uint8(want.Flags), uint8(flags))
+		}
+
+		got := newTitleTable(DecodeAudience(want.HardcoreBitField, want.FriendsBitField), flags)
+
+		// Everything that describes the title itself is copied, so only the generated bytes are compared.
+		got.ID = want.ID
+		got.TitleID = want.TitleID
+		got.TitleType = want.TitleType
+		got.Genre = want.Genre
+		got.CompanyOffset = want.CompanyOffset
+		got.ReleaseYear = want.ReleaseYear
+		got.ReleaseMonth = want.ReleaseMonth
+		got.ReleaseDay = want.ReleaseDay
+		got.RatingID = want.RatingID
+		got.MedalType = want.MedalType
+		got.TitleName = want.TitleName
+		got.Subtitle = want.Subtitle
+		got.ShortTitle = want.ShortTitle
+
+		wantBytes, gotBytes := new(bytes.Buffer), new(bytes.Buffer)
+		_ = binary.Write(wantBytes, binary.BigEndian, want)
+		_ = binary.Write(gotBytes, binary.BigEndian, got)
+		if !bytes.Equal(gotBytes.Bytes(), wantBytes.Bytes()) {
+			t.Fatalf("title %X:\ngenerated % X\nNintendo  % X", want.ID, gotBytes.Bytes()[:48], wantBytes.Bytes()[:48])
+		}
+	}
+}
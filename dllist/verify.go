@@ -0,0 +1,155 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/thumbnail"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"os"
+)
+
+// Violation is a structural problem found in a decoded dllist.bin.
+type Violation struct {
+	Table string
+	Index int
+	Err   error
+}
+
+func (v Violation) String() string {
+	if v.Index < 0 {
+		return fmt.Sprintf("%s: %v", v.Table, v.Err)
+	}
+
+	return fmt.Sprintf("%s[%d]: %v", v.Table, v.Index, v.Err)
+}
+
+// Verify checks that a decoded List is internally consistent.
+func Verify(l *List) []Violation {
+	var violations []Violation
+	add := func(table string, index int, err error) {
+		violations = append(violations, Violation{Table: table, Index: index, Err: err})
+	}
+
+	if int(l.Header.Filesize) != len(l.raw) {
+		add("Header", -1, fmt.Errorf("filesize is %d, file is %d bytes", l.Header.Filesize, len(l.raw)))
+	}
+
+	// The checksum is calculated with the CRC32 field zeroed.
+	if len(l.raw) >= headerSize {
+		data := make([]byte, len(l.raw))
+		copy(data, l.raw)
+		binary.BigEndian.PutUint32(data[8:12], 0)
+		if checksum := crc32.ChecksumIEEE(data); checksum != l.Header.CRC32 {
+			add("Header", -1, fmt.Errorf("CRC32 is 0x%08X, expected 0x%08X", l.Header.CRC32, checksum))
+		}
+	}
+
+	for i, rating := range l.RatingsTable {
+		if rating.JPEGSize == 0 {
+			continue
+		}
+
+		if _, err := l.RatingImage(i); err != nil {
+			add("RatingsTable", i, err)
+		}
+	}
+
+	for i, title := range l.TitleTable {
+		if _, err := l.Company(title.CompanyOffset); err != nil {
+			add("TitleTable", i, fmt.Errorf("company: %w", err))
+		}
+	}
+
+	for i, offset := range l.NewTitleTable {
+		if _, err := l.Title(offset); err != nil {
+			add("NewTitleTable", i, err)
+		}
+	}
+
+	for i, demo := range l.DemoTable {
+		if _, err := l.Company(demo.CompanyOffset); err != nil {
+			add("DemoTable", i, fmt.Errorf("company: %w", err))
+		}
+	}
+
+	for i, offset := range l.RecommendationTable {
+		if _, err := l.Title(offset); err != nil {
+			add("RecommendationTable", i, err)
+		}
+	}
+
+	for i, recommendation := range l.RecentRecommendationTable {
+		if _, err := l.Title(recommendation.TitleOffset); err != nil {
+			add("RecentRecommendationTable", i, err)
+		}
+	}
+
+	return violations
+}
+
+// VerifyFile decodes the dllist.bin at path and verifies it.
+func VerifyFile(path string) ([]Violation, error) {
+	l, err := decodeFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return Verify(l), nil
+}
+
+func decodeFile(path string) (*List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Decode(file)
+}
+
+// verifyThumbnailID checks that the ThumbnailID of a list matches the thumbnail.bin written next to it.
+func verifyThumbnailID(l *List, outputDir string, region constants.Region, language constants.Language) []Violation {
+	path := thumbnail.Path(outputDir, region, language)
+	id, err := thumbnail.ReadID(path)
+	if err != nil {
+		return []Violation{{Table: "Header", Index: -1, Err: fmt.Errorf("thumbnail ID: %w", err)}}
+	}
+
+	if id != l.Header.ThumbnailID {
+		return []Violation{{Table: "Header", Index: -1, Err: fmt.Errorf("thumbnail ID is %d, %s has %d", l.Header.ThumbnailID, path, id)}}
+	}
+
+	return nil
+}
+
+// VerifyDownloadLists verifies every list written by MakeDownloadList, and that its ThumbnailID
+// matches the thumbnail.bin of its region and language. It returns false if any list could not be read or has a violation.
+func VerifyDownloadLists(regions []constants.RegionMeta, outputDir string) bool {
+	ok := true
+	for _, region := range regions {
+		for _, language := range region.Languages {
+			path := ListPath(outputDir, region.Region, language)
+			l, err := decodeFile(path)
+			if err != nil {
+				fmt.Printf("%s: %v\n", path, err)
+				ok = false
+				continue
+			}
+
+			violations := append(Verify(l), verifyThumbnailID(l, outputDir, region.Region, language)...)
+			for _, violation := range violations {
+				fmt.Printf("%s: %s\n", path, violation)
+			}
+
+			if len(violations) != 0 {
+				ok = false
+				continue
+			}
+
+			fmt.Printf("%s: OK\n", path)
+		}
+	}
+
+	return ok
+}
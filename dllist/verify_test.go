@@ -0,0 +1,65 @@
+package dllist
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/thumbnail"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeThumbnailHeader(t *testing.T, path string, id uint32) {
+	t.Helper()
+
+	buffer := new(bytes.Buffer)
+	err := binary.Write(buffer, binary.BigEndian, thumbnail.Thumbnail{ThumbnailID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(path, buffer.Bytes(), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyThumbnailID(t *testing.T) {
+	dir := t.TempDir()
+	l := &List{Header: Header{ThumbnailID: 1234}}
+
+	if violations := verifyThumbnailID(l, dir, constants.PAL, constants.German); len(violations) != 1 {
+		t.Errorf("a missing thumbnail.bin gave %d violations, want 1", len(violations))
+	}
+
+	path := thumbnail.Path(dir, constants.PAL, constants.German)
+	writeThumbnailHeader(t, path, 1234)
+	if violations := verifyThumbnailID(l, dir, constants.PAL, constants.German); len(violations) != 0 {
+		t.Errorf("a matching thumbnail.bin gave violations: %v", violations)
+	}
+
+	writeThumbnailHeader(t, path, 99)
+	if violations := verifyThumbnailID(l, dir, constants.PAL, constants.German); len(violations) != 1 {
+		t.Errorf("a different thumbnail ID gave %d violations, want 1", len(violations))
+	}
+}
+
+func TestVerifyDLList(t *testing.T) {
+	l := decodeDLList(t)
+
+	// The demos of the embedded list were copied from Nintendo's list with their company offsets,
+	// which point into Nintendo's company table rather than the one of this list. MakeDemoTable
+	// replaces them, so they are the only violations Verify may find.
+	for _, violation := range Verify(l) {
+		if violation.Table != "DemoTable" || !errors.Is(violation.Err, ErrInvalidOffset) {
+			t.Errorf("unexpected violation: %s", violation)
+		}
+	}
+}
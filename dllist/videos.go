@@ -1,12 +1,7 @@
 package dllist
 
 import (
-	"NintendoChannel/constants"
-	"bufio"
-	"database/sql"
 	"fmt"
-	"os"
-	"strings"
 	"unicode/utf16"
 )
 
@@ -50,30 +45,9 @@ type PopularVideosTable struct {
 	Title       [102]uint16
 }
 
-func (l *List) MakeVideoTable() {
+func (l *List) MakeVideoTable() error {
 	l.Header.VideoTableOffset = l.GetCurrentSize()
 
-	file, err := os.Open("sql.txt")
-	if err != nil {
-		panic(err)
-	}
-	defer file.Close()
-
-	// Read the password from the file
-	scanner := bufio.NewScanner(file)
-	scanner.Scan()
-	password := scanner.Text()
-
-	// Check for errors while scanning
-	if err := scanner.Err(); err != nil {
-		panic(err)
-	}
-
-	pool, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", "rc24", password, "127.0.0.1", 3306, "rc24_nc"))
-	if err != nil {
-		panic(err)
-	}
-
 	var title [123]uint16
 	tempTitle := utf16.Encode([]rune("Go to \"New Arrivals\" >\n\"New Videos\" to watch\nany video."))
 	copy(title[:], tempTitle)
@@ -93,28 +67,22 @@ func (l *List) MakeVideoTable() {
 		Title:       title,
 	})
 
-	rows, err := pool.Query(constants.GetPopularVideoQueryString(l.language))
-	checkError(err)
+	videos, err := l.store.PopularVideos(l.language)
+	if err != nil {
+		return fmt.Errorf("querying popular videos: %w", err)
+	}
 
 	index := 1
-	for rows.Next() {
-		var id int
-		var queriedTitle string
-		var length int
-		var videoType int
-
-		err = rows.Scan(&id, &queriedTitle, &length, &videoType)
-		checkError(err)
-
+	for _, video := range videos {
 		var title [123]uint16
-		tempTitle := utf16.Encode([]rune(strings.Replace(queriedTitle, "\\n", "\n", -1)))
+		tempTitle := utf16.Encode([]rune(video.Title))
 		copy(title[:], tempTitle)
 
 		l.VideoTable = append(l.VideoTable, VideoTable{
-			ID:          uint32(id),
-			VideoLength: uint16(length),
+			ID:          video.ID,
+			VideoLength: video.Length,
 			TitleID:     0,
-			VideoType:   uint8(videoType),
+			VideoType:   video.Type,
 			Unknown:     [14]byte{},
 			Unknown2:    0,
 			RatingID:    9,
@@ -131,51 +99,25 @@ func (l *List) MakeVideoTable() {
 	}
 
 	l.Header.NumberOfVideoTables = uint32(len(l.VideoTable))
+	return nil
 }
 
-func (l *List) MakeNewVideoTable() {
+func (l *List) MakeNewVideoTable() error {
 	l.Header.NewVideoTableOffset = l.GetCurrentSize()
 
-	file, err := os.Open("sql.txt")
+	videos, err := l.store.Videos(l.language)
 	if err != nil {
-		panic(err)
-	}
-	defer file.Close()
-
-	// Read the password from the file
-	scanner := bufio.NewScanner(file)
-	scanner.Scan()
-	password := scanner.Text()
-
-	// Check for errors while scanning
-	if err := scanner.Err(); err != nil {
-		panic(err)
+		return fmt.Errorf("querying videos: %w", err)
 	}
 
-	pool, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", "rc24", password, "127.0.0.1", 3306, "rc24_nc"))
-	if err != nil {
-		panic(err)
-	}
-
-	rows, err := pool.Query(constants.GetVideoQueryString(l.language))
-	checkError(err)
-
-	for rows.Next() {
-		var id int
-		var queriedTitle string
-		var length int
-		var videoType int
-
-		err = rows.Scan(&id, &queriedTitle, &length, &videoType)
-		checkError(err)
-
+	for _, video := range videos {
 		var title [102]uint16
-		tempTitle := utf16.Encode([]rune(strings.Replace(queriedTitle, "\\n", "\n", -1)))
+		tempTitle := utf16.Encode([]rune(video.Title))
 		copy(title[:], tempTitle)
 
 		l.NewVideoTable = append(l.NewVideoTable, NewVideoTable{
-			ID:          uint32(id),
-			VideoLength: uint16(length),
+			ID:          video.ID,
+			VideoLength: video.Length,
 			TitleID:     0,
 			Unknown:     [15]byte{8, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
 			Unknown2:    0,
@@ -186,53 +128,32 @@ func (l *List) MakeNewVideoTable() {
 	}
 
 	l.Header.NumberOfNewVideoTables = uint32(len(l.NewVideoTable))
+	return nil
 }
 
-func (l *List) MakePopularVideoTable() {
-	l.Header.PopularVideoTableOffset = l.GetCurrentSize()
+// popularVideosEnabled toggles the popular videos table, which is currently left empty.
+const popularVideosEnabled = false
 
-	file, err := os.Open("sql.txt")
-	if err != nil {
-		panic(err)
-	}
-	defer file.Close()
-
-	// Read the password from the file
-	scanner := bufio.NewScanner(file)
-	scanner.Scan()
-	password := scanner.Text()
+func (l *List) MakePopularVideoTable() error {
+	l.Header.PopularVideoTableOffset = l.GetCurrentSize()
 
-	// Check for errors while scanning
-	if err := scanner.Err(); err != nil {
-		panic(err)
+	if !popularVideosEnabled {
+		return nil
 	}
 
-	pool, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", "rc24", password, "127.0.0.1", 3306, "rc24_nc"))
+	videos, err := l.store.PopularVideos(l.language)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("querying popular videos: %w", err)
 	}
 
-	return
-
-	rows, err := pool.Query(constants.GetPopularVideoQueryString(l.language))
-	checkError(err)
-
-	for rows.Next() {
-		var id int
-		var queriedTitle string
-		var length int
-		var videoType int
-
-		err = rows.Scan(&id, &queriedTitle, &length, &videoType)
-		checkError(err)
-
+	for _, video := range videos {
 		var title [102]uint16
-		tempTitle := utf16.Encode([]rune(strings.Replace(queriedTitle, "\\n", "\n", -1)))
+		tempTitle := utf16.Encode([]rune(video.Title))
 		copy(title[:], tempTitle)
 
 		l.PopularVideosTable = append(l.PopularVideosTable, PopularVideosTable{
-			ID:          uint32(id),
-			VideoLength: uint16(length),
+			ID:          video.ID,
+			VideoLength: video.Length,
 			TitleID:     0,
 			BarColor:    0,
 			RatingID:    9,
@@ -244,4 +165,5 @@ func (l *List) MakePopularVideoTable() {
 	}
 
 	l.Header.NumberOfPopularVideoTables = uint32(len(l.PopularVideosTable))
+	return nil
 }
@@ -0,0 +1,61 @@
+package gametdb
+
+import (
+	"NintendoChannel/atomicfile"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// FirstSeen records when each game first appeared in GameTDB, keyed by "<database>/<game ID>".
+type FirstSeen map[string]time.Time
+
+// Get returns when the game with id first appeared in database, or the zero time if
+// it was already there when tracking started.
+func (f FirstSeen) Get(database, id string) time.Time {
+	return f[database+"/"+id]
+}
+
+// TrackFirstSeen loads the first seen times stored at path, records now for every loaded
+// game that is not in it yet and saves the result. When path does not exist yet, every
+// game is recorded with the zero time so that existing games are not considered new.
+func TrackFirstSeen(path string, now time.Time) (FirstSeen, error) {
+	firstSeen := FirstSeen{}
+	seen := now
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		seen = time.Time{}
+	} else if err != nil {
+		return nil, err
+	} else {
+		err = json.Unmarshal(contents, &firstSeen)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	for i, tdb := range []*GameTDB{WiiTDB, DSTDB, ThreeDSTDB} {
+		for _, game := range tdb.Games {
+			key := tdbNames[i] + "/" + game.ID
+			if _, ok := firstSeen[key]; !ok {
+				firstSeen[key] = seen
+			}
+		}
+	}
+
+	contents, err = json.MarshalIndent(firstSeen, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	err = atomicfile.WriteFile(path, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstSeen, nil
+}
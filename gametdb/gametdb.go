@@ -1,21 +1,39 @@
 package gametdb
 
 import (
-	"archive/zip"
+	"NintendoChannel/config"
 	"encoding/xml"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
-	"os"
+	"sort"
+	"time"
 )
 
 type GameTDB struct {
-	XMLName   xml.Name  `xml:"datafile"`
+	XMLName xml.Name `xml:"datafile"`
+	// Meta holds the <WiiTDB version="..."> style element describing this datafile.
+	Meta      []Meta    `xml:",any"`
 	Companies Companies `xml:"companies"`
 	Games     []Game    `xml:"game"`
 }
 
+type Meta struct {
+	XMLName xml.Name
+	Version string `xml:"version,attr"`
+	Games   string `xml:"games,attr"`
+}
+
+// Version returns the version of the datafile, or an empty string if it has none.
+func (g *GameTDB) Version() string {
+	for _, meta := range g.Meta {
+		if meta.Version != "" {
+			return meta.Version
+		}
+	}
+
+	return ""
+}
+
 type Companies struct {
 	Companies []Company `xml:"company"`
 }
@@ -26,18 +44,25 @@ type Company struct {
 }
 
 type Game struct {
-	XMLName     xml.Name    `xml:"game"`
-	ID          string      `xml:"id"`
-	Type        string      `xml:"type"`
-	Region      string      `xml:"region"`
-	Locale      []GameMeta  `xml:"locale"`
-	ReleaseDate Date        `xml:"date"`
-	Rating      Rating      `xml:"rating"`
-	Publisher   string      `xml:"publisher"`
-	Controllers Controllers `xml:"input"`
-	Features    Features    `xml:"wi-fi"`
-	Languages   string      `xml:"languages"`
-	Genre       string      `xml:"genre"`
+	XMLName xml.Name `xml:"game"`
+	Name    string   `xml:"name,attr"`
+	ID      string   `xml:"id"`
+	// AlternateIDs are other IDs the same game was released under.
+	AlternateIDs []string    `xml:"alternate_id"`
+	Type         string      `xml:"type"`
+	Region       string      `xml:"region"`
+	Languages    string      `xml:"languages"`
+	Locale       []GameMeta  `xml:"locale"`
+	Developer    string      `xml:"developer"`
+	Publisher    string      `xml:"publisher"`
+	ReleaseDate  Date        `xml:"date"`
+	Genre        string      `xml:"genre"`
+	Rating       Rating      `xml:"rating"`
+	Features     Features    `xml:"wi-fi"`
+	Controllers  Controllers `xml:"input"`
+	Save         Save        `xml:"save"`
+	ROMs         []ROM       `xml:"rom"`
+	Case         Case        `xml:"case"`
 }
 
 type GameMeta struct {
@@ -52,6 +77,16 @@ type Date struct {
 	Day   string `xml:"day,attr"`
 }
 
+// Time returns the date as a time, reporting false if the year, month or day is missing or invalid.
+func (d Date) Time() (time.Time, bool) {
+	date, err := time.Parse("2006-1-2", fmt.Sprintf("%s-%s-%s", d.Year, d.Month, d.Day))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
 type Rating struct {
 	Type       string   `xml:"type,attr"`
 	Value      string   `xml:"value,attr"`
@@ -59,10 +94,26 @@ type Rating struct {
 }
 
 type Controllers struct {
-	Players    uint8 `xml:"players,attr"`
-	Controller []struct {
-		Type string `xml:"type,attr"`
-	} `xml:"control"`
+	Players    uint8     `xml:"players,attr"`
+	Controller []Control `xml:"control"`
+}
+
+// Control is a controller or peripheral a game can be played with.
+type Control struct {
+	Type string `xml:"type,attr"`
+	// Required is set when the game cannot be played without this control.
+	Required bool `xml:"required,attr"`
+}
+
+// Required reports whether controlType must be used to play the game.
+func (c *Controllers) Required(controlType string) bool {
+	for _, control := range c.Controller {
+		if control.Type == controlType {
+			return control.Required
+		}
+	}
+
+	return false
 }
 
 type Features struct {
@@ -70,6 +121,37 @@ type Features struct {
 	Feature       []string `xml:"feature"`
 }
 
+// Has reports whether the game lists feature.
+func (f *Features) Has(feature string) bool {
+	for _, s := range f.Feature {
+		if s == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Save struct {
+	Blocks uint16 `xml:"blocks,attr"`
+	Copy   string `xml:"copy,attr"`
+	Move   string `xml:"move,attr"`
+}
+
+type ROM struct {
+	Version string `xml:"version,attr"`
+	Name    string `xml:"name,attr"`
+	Size    uint64 `xml:"size,attr"`
+	CRC     string `xml:"crc,attr"`
+	MD5     string `xml:"md5,attr"`
+	SHA1    string `xml:"sha1,attr"`
+}
+
+type Case struct {
+	Color    string `xml:"color,attr"`
+	Versions string `xml:"versions,attr"`
+}
+
 var (
 	WiiTDB     *GameTDB
 	DSTDB      *GameTDB
@@ -78,56 +160,80 @@ var (
 	tdbNames = []string{"wiitdb", "dstdb", "3dstdb"}
 )
 
-func checkError(err error) {
-	if err != nil {
-		log.Fatalf("GameTDB XML downloader has encountered a fatal error! Reason: %v\n", err)
-	}
-}
-
-func PrepareGameTDB() {
-	fmt.Println("Downloading GameTDB XML's...")
+// PrepareGameTDB loads the Wii, DS and 3DS databases from their pinned snapshots or configured sources.
+func PrepareGameTDB(cfg config.GameTDB) error {
+	fmt.Println("Loading GameTDB XML's...")
 	client := &http.Client{}
 
 	for i, name := range tdbNames {
-		req, err := http.NewRequest("GET", fmt.Sprintf("https://www.gametdb.com/%s.zip", name), nil)
-		checkError(err)
+		source := []string{cfg.WiiURL, cfg.DSURL, cfg.ThreeDSURL}[i]
+		gameTDB, err := loadGameTDB(client, cfg, name, source)
+		if err != nil {
+			return fmt.Errorf("gametdb: %s: %w", name, err)
+		}
+
+		fmt.Printf("Using %s version %s\n", name, gameTDB.Version())
 
-		req.Header.Set("User-Agent", "WiiLink Nintendo Channel File Generator/0.1")
+		switch i {
+		case 0:
+			WiiTDB = gameTDB
+		case 1:
+			DSTDB = gameTDB
+		case 2:
+			ThreeDSTDB = gameTDB
+		}
+	}
 
-		response, err := client.Do(req)
-		checkError(err)
+	return nil
+}
 
-		defer response.Body.Close()
-		contents, err := io.ReadAll(response.Body)
-		checkError(err)
+func loadGameTDB(client *http.Client, cfg config.GameTDB, name, source string) (*GameTDB, error) {
+	var contents []byte
+	var err error
+	pinned := cfg.Pinned[name]
+	if pinned != "" {
+		contents, err = readSnapshot(cfg.SnapshotDir, name, pinned)
+	} else {
+		contents, err = readSource(client, cfg.CacheDir, name, source)
+	}
 
-		err = os.WriteFile("tdb.zip", contents, 0666)
-		checkError(err)
+	if err != nil {
+		return nil, err
+	}
 
-		// We need to unzip before we proceed to unmarshalling
-		r, err := zip.OpenReader("tdb.zip")
-		checkError(err)
+	var gameTDB GameTDB
+	err = xml.Unmarshal(contents, &gameTDB)
+	if err != nil {
+		return nil, err
+	}
 
-		fp, err := r.Open(fmt.Sprintf("%s.xml", name))
-		checkError(err)
+	if pinned != "" && gameTDB.Version() != pinned {
+		return nil, fmt.Errorf("snapshot is version %q, expected %q", gameTDB.Version(), pinned)
+	}
 
-		contents, err = io.ReadAll(fp)
-		checkError(err)
+	if pinned == "" && cfg.SnapshotDir != "" {
+		err = writeSnapshot(cfg.SnapshotDir, name, gameTDB.Version(), contents)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-		var gameTDB GameTDB
-		err = xml.Unmarshal(contents, &gameTDB)
-		checkError(err)
+	sortGames(gameTDB.Games)
+	return &gameTDB, nil
+}
 
-		switch i {
-		case 0:
-			WiiTDB = &gameTDB
-		case 1:
-			DSTDB = &gameTDB
-		case 2:
-			ThreeDSTDB = &gameTDB
+// sortGames orders games by their first title. This is done once at load time so the
+// list workers can share the slices without modifying them.
+func sortGames(games []Game) {
+	title := func(game Game) string {
+		if len(game.Locale) == 0 {
+			return ""
 		}
 
-		err = os.Remove("tdb.zip")
-		checkError(err)
+		return game.Locale[0].Title
 	}
+
+	sort.SliceStable(games, func(i, j int) bool {
+		return title(games[i]) < title(games[j])
+	})
 }
@@ -0,0 +1,207 @@
+package gametdb
+
+import (
+	"NintendoChannel/atomicfile"
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cacheEntry holds the validators of a cached archive, used to revalidate it with the server.
+type cacheEntry struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag"`
+	LastModified string `json:"last_modified"`
+}
+
+// readSource returns the XML of the database name from source, which is either a URL to a
+// zip archive or the path to a local .xml or .zip file.
+func readSource(client *http.Client, cacheDir, name, source string) ([]byte, error) {
+	var contents []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		contents, err = download(client, cacheDir, name, source)
+	} else {
+		contents, err = os.ReadFile(source)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(strings.ToLower(source), ".xml") {
+		return contents, nil
+	}
+
+	return unzip(contents, name)
+}
+
+// download fetches the archive at url. If cacheDir is set, the archive is kept there and only
+// downloaded again if the server reports that it changed. A cached archive is also used when
+// the server cannot be reached.
+func download(client *http.Client, cacheDir, name, url string) ([]byte, error) {
+	archivePath := filepath.Join(cacheDir, name+".zip")
+	entryPath := filepath.Join(cacheDir, name+".json")
+
+	var entry cacheEntry
+	var cached []byte
+	if cacheDir != "" {
+		var err error
+		cached, entry, err = readCache(archivePath, entryPath)
+		if err != nil {
+			return nil, err
+		}
+
+		// A cache for another URL cannot be revalidated.
+		if entry.URL != url {
+			cached = nil
+		}
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "WiiLink Nintendo Channel File Generator/0.1")
+	if cached != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	response, err := client.Do(req)
+	if err != nil {
+		if cached != nil {
+			fmt.Printf("Could not reach %s, using cached %s: %v\n", url, name, err)
+			return cached, nil
+		}
+
+		return nil, err
+	}
+
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotModified && cached != nil {
+		return cached, nil
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", url, response.Status)
+	}
+
+	contents, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheDir != "" {
+		entry = cacheEntry{
+			URL:          url,
+			ETag:         response.Header.Get("ETag"),
+			LastModified: response.Header.Get("Last-Modified"),
+		}
+
+		err = writeCache(archivePath, entryPath, contents, entry)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return contents, nil
+}
+
+// readCache returns the cached archive and its validators, or nothing if it isn't cached yet.
+func readCache(archivePath, entryPath string) ([]byte, cacheEntry, error) {
+	var entry cacheEntry
+	contents, err := os.ReadFile(entryPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, entry, nil
+	} else if err != nil {
+		return nil, entry, err
+	}
+
+	err = json.Unmarshal(contents, &entry)
+	if err != nil {
+		return nil, entry, fmt.Errorf("parsing %s: %w", entryPath, err)
+	}
+
+	archive, err := os.ReadFile(archivePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, entry, nil
+	} else if err != nil {
+		return nil, entry, err
+	}
+
+	return archive, entry, nil
+}
+
+func writeCache(archivePath, entryPath string, archive []byte, entry cacheEntry) error {
+	err := atomicfile.WriteFile(archivePath, archive)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(entryPath, contents)
+}
+
+func unzip(contents []byte, name string) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return nil, err
+	}
+
+	fp, err := r.Open(fmt.Sprintf("%s.xml", name))
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	return io.ReadAll(fp)
+}
+
+// SnapshotPath returns where the XML of version of the database name is stored.
+func SnapshotPath(snapshotDir, name, version string) string {
+	return filepath.Join(snapshotDir, fmt.Sprintf("%s-%s.xml", name, version))
+}
+
+func readSnapshot(snapshotDir, name, version string) ([]byte, error) {
+	if snapshotDir == "" {
+		return nil, fmt.Errorf("version %s is pinned but no snapshot directory is set", version)
+	}
+
+	return os.ReadFile(SnapshotPath(snapshotDir, name, version))
+}
+
+// writeSnapshot stores contents as version of the database name, unless that version is already stored.
+func writeSnapshot(snapshotDir, name, version string, contents []byte) error {
+	if version == "" {
+		return fmt.Errorf("datafile has no version to snapshot")
+	}
+
+	path := SnapshotPath(snapshotDir, name, version)
+	_, err := os.Stat(path)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return atomicfile.WriteFile(path, contents)
+}
@@ -4,23 +4,22 @@ go 1.19
 
 require (
 	github.com/SketchMaster2001/libwc24crypt v0.0.0-20221114191055-b7fc8eef33ba
-	github.com/kaitai-io/kaitai_struct_go_runtime v0.10.0
 	github.com/mitchellh/go-wordwrap v1.0.1
 	github.com/wii-tools/lzx v0.0.0-20221114001118-aaec5e424e43
 )
 
 require (
+	github.com/disintegration/imaging v1.6.2
+	github.com/go-sql-driver/mysql v1.7.1
 	github.com/h2non/bimg v1.1.9
+	github.com/jackc/pgconn v1.13.0
 	github.com/jackc/pgx/v4 v4.17.2
 	golang.org/x/image v0.2.0
 	golang.org/x/text v0.5.0
 )
 
 require (
-	github.com/disintegration/imaging v1.6.2 // indirect
-	github.com/go-sql-driver/mysql v1.7.1 // indirect
 	github.com/jackc/chunkreader/v2 v2.0.1 // indirect
-	github.com/jackc/pgconn v1.13.0 // indirect
 	github.com/jackc/pgio v1.0.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgproto3/v2 v2.3.1 // indirect
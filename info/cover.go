@@ -0,0 +1,286 @@
+package info
+
+import (
+	"NintendoChannel/atomicfile"
+	"NintendoChannel/config"
+	"NintendoChannel/constants"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// errNoCoverArt is returned when GameTDB has no cover for a game.
+var errNoCoverArt = errors.New("no cover art")
+
+// missingCoverRecheck is how long GameTDB is not asked again for a cover it did not have.
+const missingCoverRecheck = 7 * 24 * time.Hour
+
+// coverArt finds the covers of games in the local art directory, the cache or GameTDB.
+// It is shared by every worker, and a cover is only downloaded once even if several
+// workers ask for it at the same time.
+type coverArt struct {
+	config config.CoverArt
+	client *http.Client
+
+	mutex sync.Mutex
+	// fetching holds a lock per cover URL, so only one worker downloads it.
+	fetching map[string]*sync.Mutex
+	// missing holds the URLs GameTDB has no cover for, so they are requested once per run
+	// even without a cache directory.
+	missing map[string]bool
+	// fetched holds the covers downloaded during this run when there is no cache directory,
+	// so a cover downloaded to hash it is not downloaded again to draw it.
+	fetched map[string][]byte
+}
+
+var covers = newCoverArt(config.Default().CoverArt)
+
+func newCoverArt(cfg config.CoverArt) *coverArt {
+	return &coverArt{
+		config:   cfg,
+		client:   &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second},
+		fetching: map[string]*sync.Mutex{},
+		missing:  map[string]bool{},
+		fetched:  map[string][]byte{},
+	}
+}
+
+// PrepareCoverArt sets where covers are taken from. It must be called before any info file is made.
+func PrepareCoverArt(cfg config.CoverArt) {
+	covers = newCoverArt(cfg)
+}
+
+// CoverArtHash returns the hash of the cover an info file would use, or of the placeholder if there is none.
+// Cached covers, and covers GameTDB is known not to have, are hashed without downloading anything.
+func CoverArtHash(titleType constants.TitleType, region constants.Region, gameID string) string {
+	contents, err := covers.local(titleType, region, gameID)
+	if err == nil {
+		return hashBytes(contents)
+	}
+
+	url := CoverArtURL(titleType, region, gameID)
+	if hash, err := covers.cachedHash(url); err == nil {
+		return hash
+	}
+
+	if covers.isMissing(url) {
+		return hashBytes(consoleToTempImageType[titleType])
+	}
+
+	contents, _ = covers.get(titleType, region, gameID)
+	return hashBytes(contents)
+}
+
+// get returns the cover of a game, or the placeholder of its console and false if it has none.
+func (c *coverArt) get(titleType constants.TitleType, region constants.Region, gameID string) ([]byte, bool) {
+	contents, err := c.local(titleType, region, gameID)
+	if err == nil {
+		return contents, true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("Could not read local cover art for %s: %v\n", gameID, err)
+	}
+
+	contents, err = c.remote(CoverArtURL(titleType, region, gameID))
+	if err == nil {
+		return contents, true
+	} else if !errors.Is(err, errNoCoverArt) {
+		fmt.Printf("Could not download cover art for %s, using a placeholder: %v\n", gameID, err)
+	}
+
+	return consoleToTempImageType[titleType], false
+}
+
+// local reads a cover from the local art directory, as <console>/<region>/<game ID>.<ext>
+// or <console>/<game ID>.<ext>.
+func (c *coverArt) local(titleType constants.TitleType, region constants.Region, gameID string) ([]byte, error) {
+	if c.config.LocalDir == "" {
+		return nil, fs.ErrNotExist
+	}
+
+	for _, dir := range []string{
+		filepath.Join(c.config.LocalDir, titleTypeToStr[titleType], regionToStr[region]),
+		filepath.Join(c.config.LocalDir, titleTypeToStr[titleType]),
+	} {
+		for _, extension := range []string{".png", ".jpg", ".jpeg"} {
+			contents, err := os.ReadFile(filepath.Join(dir, gameID+extension))
+			if !errors.Is(err, fs.ErrNotExist) {
+				return contents, err
+			}
+		}
+	}
+
+	return nil, fs.ErrNotExist
+}
+
+// remote returns the cover at url from the cache, downloading it first if needed.
+func (c *coverArt) remote(url string) ([]byte, error) {
+	c.mutex.Lock()
+	lock, ok := c.fetching[url]
+	if !ok {
+		lock = &sync.Mutex{}
+		c.fetching[url] = lock
+	}
+	c.mutex.Unlock()
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if c.isMissing(url) {
+		return nil, errNoCoverArt
+	}
+
+	c.mutex.Lock()
+	contents, ok := c.fetched[url]
+	c.mutex.Unlock()
+	if ok {
+		return contents, nil
+	}
+
+	contents, err := c.cached(url)
+	if err == nil {
+		return contents, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	contents, err = c.download(url)
+	if errors.Is(err, errNoCoverArt) {
+		c.markMissing(url)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return contents, c.store(url, contents)
+}
+
+// download fetches url, retrying with exponential backoff on network and server errors.
+func (c *coverArt) download(url string) ([]byte, error) {
+	var err error
+	for attempt := 0; attempt <= c.config.Retries; attempt++ {
+		if attempt != 0 {
+			time.Sleep(time.Second << (attempt - 1))
+		}
+
+		var contents []byte
+		var retry bool
+		contents, retry, err = c.downloadOnce(url)
+		if !retry {
+			return contents, err
+		}
+	}
+
+	return nil, err
+}
+
+func (c *coverArt) downloadOnce(url string) ([]byte, bool, error) {
+	resp, err := c.client.Get(url)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		contents, err := io.ReadAll(resp.Body)
+		return contents, err != nil, err
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, false, errNoCoverArt
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return nil, true, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	default:
+		return nil, false, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+}
+
+// The cache is content addressed. objects/<hash> holds each distinct image once and
+// urls/<hash of URL> holds the hash of the image downloaded from that URL.
+// missing/<hash of URL> records that GameTDB had no cover at that URL, as of its modification time.
+
+func (c *coverArt) cached(url string) ([]byte, error) {
+	hash, err := c.cachedHash(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(filepath.Join(c.config.CacheDir, "objects", hash))
+}
+
+// cachedHash returns the hash of the cached cover downloaded from url without reading it.
+func (c *coverArt) cachedHash(url string) (string, error) {
+	if c.config.CacheDir == "" {
+		return "", fs.ErrNotExist
+	}
+
+	hash, err := os.ReadFile(filepath.Join(c.config.CacheDir, "urls", hashBytes([]byte(url))))
+	if err != nil {
+		return "", err
+	}
+
+	_, err = os.Stat(filepath.Join(c.config.CacheDir, "objects", string(hash)))
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// isMissing reports whether GameTDB had no cover at url during this run or, according to the cache,
+// within missingCoverRecheck.
+func (c *coverArt) isMissing(url string) bool {
+	c.mutex.Lock()
+	missing := c.missing[url]
+	c.mutex.Unlock()
+	if missing || c.config.CacheDir == "" {
+		return missing
+	}
+
+	stat, err := os.Stat(filepath.Join(c.config.CacheDir, "missing", hashBytes([]byte(url))))
+	return err == nil && time.Since(stat.ModTime()) < missingCoverRecheck
+}
+
+func (c *coverArt) markMissing(url string) {
+	c.mutex.Lock()
+	c.missing[url] = true
+	c.mutex.Unlock()
+
+	if c.config.CacheDir == "" {
+		return
+	}
+
+	err := atomicfile.WriteFile(filepath.Join(c.config.CacheDir, "missing", hashBytes([]byte(url))), []byte(url))
+	if err != nil {
+		fmt.Printf("Could not cache that %s has no cover art: %v\n", url, err)
+	}
+}
+
+func (c *coverArt) store(url string, contents []byte) error {
+	if c.config.CacheDir == "" {
+		c.mutex.Lock()
+		c.fetched[url] = contents
+		c.mutex.Unlock()
+		return nil
+	}
+
+	hash := hashBytes(contents)
+	err := atomicfile.WriteFile(filepath.Join(c.config.CacheDir, "objects", hash), contents)
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(filepath.Join(c.config.CacheDir, "urls", hashBytes([]byte(url))), []byte(hash))
+}
+
+func hashBytes(contents []byte) string {
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:])
+}
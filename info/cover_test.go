@@ -0,0 +1,43 @@
+package info
+
+import (
+	"NintendoChannel/config"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCoverArtFetchedOnce(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/missing.png" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte("cover"))
+	}))
+	defer server.Close()
+
+	// Without a cache directory, covers are kept in memory for the rest of the run.
+	c := newCoverArt(config.CoverArt{TimeoutSeconds: 5})
+	for j := 0; j < 2; j++ {
+		contents, err := c.remote(server.URL + "/cover.png")
+		if err != nil || !bytes.Equal(contents, []byte("cover")) {
+			t.Fatalf("remote = %q, %v", contents, err)
+		}
+
+		_, err = c.remote(server.URL + "/missing.png")
+		if !errors.Is(err, errNoCoverArt) {
+			t.Fatalf("remote of a missing cover = %v, want errNoCoverArt", err)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("made %d requests, want 2", requests)
+	}
+}
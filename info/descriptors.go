@@ -0,0 +1,267 @@
+package info
+
+import (
+	"NintendoChannel/constants"
+	"NintendoChannel/gametdb"
+	"bytes"
+	"errors"
+	"fmt"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io/fs"
+	"os"
+	"strings"
+	"unicode"
+)
+
+const (
+	descriptorWidth    = 350
+	descriptorHeight   = 16
+	descriptorFontSize = 14
+	// MaxDescriptors is how many descriptors an info file has room for.
+	MaxDescriptors = 7
+)
+
+// ratingBoards maps the rating types of GameTDB to their rating boards.
+var ratingBoards = map[string]constants.RatingGroup{
+	"CERO": constants.CERO,
+	"ESRB": constants.ESRB,
+	"PEGI": constants.PEGI,
+}
+
+// Descriptor is a normalized content descriptor of a title.
+type Descriptor struct {
+	Board constants.RatingGroup
+	// Key is the descriptor in constants.Descriptors, or empty for boards whose descriptors are free text.
+	Key string `json:",omitempty"`
+	// Text is the free text of the descriptor, used when there is no Key.
+	Text string `json:",omitempty"`
+}
+
+// descriptorAliases maps every normalized way of writing a fixed descriptor to it, for each board.
+var descriptorAliases = makeDescriptorAliases()
+
+func makeDescriptorAliases() map[constants.RatingGroup]map[string]*constants.Descriptor {
+	aliases := map[constants.RatingGroup]map[string]*constants.Descriptor{}
+	for board, descriptors := range constants.Descriptors {
+		aliases[board] = map[string]*constants.Descriptor{}
+		for j := range descriptors {
+			descriptor := &descriptors[j]
+			names := append([]string{descriptor.Key}, descriptor.Aliases...)
+			for _, name := range descriptor.Names {
+				names = append(names, name)
+			}
+
+			for _, name := range names {
+				aliases[board][normalizeDescriptor(name)] = descriptor
+			}
+		}
+	}
+
+	return aliases
+}
+
+// normalizeDescriptor lowercases a descriptor and reduces anything but letters and numbers to single spaces.
+func normalizeDescriptor(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), " ")
+}
+
+// NormalizeDescriptors returns the descriptors of a rating, without duplicates and at most MaxDescriptors of them.
+// Descriptors that are not in the fixed set of their board are returned as unknown.
+func NormalizeDescriptors(rating gametdb.Rating) (descriptors []Descriptor, unknown []string) {
+	board, ok := ratingBoards[strings.ToUpper(rating.Type)]
+	if !ok {
+		return nil, nil
+	}
+
+	seen := map[Descriptor]bool{}
+	for _, text := range rating.Descriptor {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		descriptor := Descriptor{Board: board, Text: capitalizeString(text)}
+		if aliases, ok := descriptorAliases[board]; ok {
+			fixed, ok := aliases[normalizeDescriptor(text)]
+			if !ok {
+				unknown = append(unknown, text)
+				continue
+			}
+
+			descriptor = Descriptor{Board: board, Key: fixed.Key}
+		}
+
+		if seen[descriptor] || len(descriptors) == MaxDescriptors {
+			continue
+		}
+
+		seen[descriptor] = true
+		descriptors = append(descriptors, descriptor)
+	}
+
+	return descriptors, unknown
+}
+
+// fixed returns the descriptor in constants.Descriptors, if it is one of a fixed set.
+func (d Descriptor) fixed() (*constants.Descriptor, bool) {
+	for j, descriptor := range constants.Descriptors[d.Board] {
+		if d.Key != "" && descriptor.Key == d.Key {
+			return &constants.Descriptors[d.Board][j], true
+		}
+	}
+
+	return nil, false
+}
+
+// Label returns the text of the descriptor in a language, falling back to English.
+func (d Descriptor) Label(language constants.Language) string {
+	fixed, ok := d.fixed()
+	if !ok {
+		return d.Text
+	}
+
+	if name, ok := fixed.Names[language]; ok {
+		return name
+	}
+
+	return fixed.Names[constants.English]
+}
+
+// descriptorFonts are the fonts rating descriptors are drawn with. Every character is drawn with
+// the first font that has it. Go Regular is always last, so Latin text can be drawn without any font files.
+var descriptorFonts = []*sfnt.Font{mustParseFont(goregular.TTF)}
+
+func mustParseFont(contents []byte) *sfnt.Font {
+	f, err := opentype.Parse(contents)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+// PrepareDescriptorFonts loads the fonts at paths for drawing rating descriptors, in order of preference.
+// Fonts that do not exist are skipped, and a warning is printed for every descriptor the remaining fonts cannot draw.
+func PrepareDescriptorFonts(paths []string) error {
+	fonts := []*sfnt.Font{}
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Printf("WARNING: descriptor font %s does not exist, skipping it\n", path)
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		f, err := opentype.Parse(contents)
+		if err != nil {
+			return fmt.Errorf("parsing descriptor font %s: %w", path, err)
+		}
+
+		fonts = append(fonts, f)
+	}
+
+	descriptorFonts = append(fonts, mustParseFont(goregular.TTF))
+
+	for _, descriptors := range constants.Descriptors {
+		for _, descriptor := range descriptors {
+			for language, name := range descriptor.Names {
+				if r, ok := missingGlyph(name); ok {
+					fmt.Printf("WARNING: no descriptor font can draw %q of %q, info files of titles with the %s descriptor in %s are still written, but without it and the descriptors after it. Add a font that has it to descriptor_fonts.\n", r, name, descriptor.Key, language)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// fontFor returns the index of the first descriptor font that has a glyph for r, or false if none has.
+func fontFor(buffer *sfnt.Buffer, r rune) (int, bool) {
+	for j, f := range descriptorFonts {
+		index, err := f.GlyphIndex(buffer, r)
+		if err == nil && index != 0 {
+			return j, true
+		}
+	}
+
+	return 0, false
+}
+
+// missingGlyph returns the first character of text that no descriptor font can draw.
+func missingGlyph(text string) (rune, bool) {
+	var buffer sfnt.Buffer
+	for _, r := range text {
+		if _, ok := fontFor(&buffer, r); !ok && !unicode.IsSpace(r) {
+			return r, true
+		}
+	}
+
+	return 0, false
+}
+
+// RenderDescriptor draws a rating descriptor left aligned on a white 350x16 strip and encodes it as a JPEG.
+// Text that does not fit is cut off.
+// Text with a character that no descriptor font can draw is an error rather than a strip of empty boxes.
+func RenderDescriptor(text string) ([]byte, error) {
+	if r, ok := missingGlyph(text); ok {
+		return nil, fmt.Errorf("no descriptor font can draw %q, add a font that has it to descriptor_fonts", r)
+	}
+
+	// Faces keep state while drawing, so every strip gets its own.
+	faces := make([]font.Face, len(descriptorFonts))
+	for j, f := range descriptorFonts {
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{
+			Size:    descriptorFontSize,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		defer face.Close()
+		faces[j] = face
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, descriptorWidth, descriptorHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+
+	// Center the line vertically using the metrics of the preferred font.
+	metrics := faces[0].Metrics()
+	baseline := (descriptorHeight + metrics.Ascent.Ceil() - metrics.Descent.Ceil()) / 2
+
+	drawer := font.Drawer{
+		Dst: img,
+		Src: image.NewUniform(color.Black),
+		Dot: fixed.P(1, baseline),
+	}
+
+	var buffer sfnt.Buffer
+	for _, r := range text {
+		drawer.Face = faces[len(faces)-1]
+		if j, ok := fontFor(&buffer, r); ok {
+			drawer.Face = faces[j]
+		}
+
+		drawer.DrawString(string(r))
+	}
+
+	output := new(bytes.Buffer)
+	err := jpeg.Encode(output, img, &jpeg.Options{Quality: 90})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
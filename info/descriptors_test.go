@@ -0,0 +1,164 @@
+package info
+
+import (
+	"NintendoChannel/constants"
+	"bytes"
+	"flag"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden images in testdata")
+
+// goldenTolerance is how far a channel may differ from the golden image, to allow for JPEG encoder changes.
+const goldenTolerance = 16
+
+// esrbDescriptors are sample free text descriptors, as ESRB has no fixed set.
+var esrbDescriptors = []string{"Fantasy Violence", "Mild Language", "Comic Mischief"}
+
+// renderBoard draws the descriptors of a board in a language, one strip below the other.
+func renderBoard(board constants.RatingGroup, language constants.Language) (*image.RGBA, error) {
+	var descriptors []Descriptor
+	if board == constants.ESRB {
+		for _, text := range esrbDescriptors {
+			descriptors = append(descriptors, Descriptor{Board: board, Text: text})
+		}
+	} else {
+		for _, descriptor := range constants.Descriptors[board] {
+			descriptors = append(descriptors, Descriptor{Board: board, Key: descriptor.Key})
+		}
+	}
+
+	sheet := image.NewRGBA(image.Rect(0, 0, descriptorWidth, descriptorHeight*len(descriptors)))
+	for j, descriptor := range descriptors {
+		contents, err := RenderDescriptor(descriptor.Label(language))
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", descriptor.Label(language), err)
+		}
+
+		strip, err := jpeg.Decode(bytes.NewReader(contents))
+		if err != nil {
+			return nil, err
+		}
+
+		if strip.Bounds().Dx() != descriptorWidth || strip.Bounds().Dy() != descriptorHeight {
+			return nil, fmt.Errorf("%q is %v, want %dx%d", descriptor.Label(language), strip.Bounds(), descriptorWidth, descriptorHeight)
+		}
+
+		draw.Draw(sheet, strip.Bounds().Add(image.Pt(0, descriptorHeight*j)), strip, image.Point{}, draw.Src)
+	}
+
+	return sheet, nil
+}
+
+func TestRenderDescriptorGolden(t *testing.T) {
+	boards := map[constants.RatingGroup]string{
+		constants.CERO: "cero",
+		constants.ESRB: "esrb",
+		constants.PEGI: "pegi",
+	}
+
+	for _, region := range constants.Regions {
+		languages := region.Languages
+		switch region.RatingGroup {
+		case constants.CERO:
+			// Japanese needs a font with Japanese glyphs, which is not redistributable, see TestRenderDescriptorMissingGlyph.
+			languages = []constants.Language{constants.English}
+		case constants.ESRB:
+			// ESRB descriptors are free text, which is drawn the same in every language.
+			languages = []constants.Language{constants.English}
+		}
+
+		for _, language := range languages {
+			name := fmt.Sprintf("%s_%s", boards[region.RatingGroup], language)
+			t.Run(name, func(t *testing.T) {
+				sheet, err := renderBoard(region.RatingGroup, language)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				compareGolden(t, filepath.Join("testdata", "descriptors", name+".png"), sheet)
+			})
+		}
+	}
+}
+
+func compareGolden(t *testing.T, path string, got *image.RGBA) {
+	t.Helper()
+
+	if *update {
+		buffer := new(bytes.Buffer)
+		err := png.Encode(buffer, got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(path, buffer.Bytes(), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./info -update to create it)", err)
+	}
+
+	want, err := png.Decode(bytes.NewReader(contents))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want.Bounds() != got.Bounds() {
+		t.Fatalf("image is %v, golden image is %v", got.Bounds(), want.Bounds())
+	}
+
+	differences := 0
+	for y := got.Bounds().Min.Y; y < got.Bounds().Max.Y; y++ {
+		for x := got.Bounds().Min.X; x < got.Bounds().Max.X; x++ {
+			r1, g1, b1, _ := got.At(x, y).RGBA()
+			r2, g2, b2, _ := want.At(x, y).RGBA()
+			if channelDiff(r1, r2) > goldenTolerance || channelDiff(g1, g2) > goldenTolerance || channelDiff(b1, b2) > goldenTolerance {
+				differences++
+			}
+		}
+	}
+
+	if differences != 0 {
+		t.Errorf("%d pixels differ from %s (run go test ./info -update if the change is intended)", differences, path)
+	}
+}
+
+func channelDiff(a, b uint32) uint32 {
+	a, b = a>>8, b>>8
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
+
+func TestRenderDescriptorMissingGlyph(t *testing.T) {
+	// Only Go Regular is loaded in tests, which has no Japanese glyphs.
+	for _, descriptor := range constants.Descriptors[constants.CERO] {
+		label := descriptor.Names[constants.Japanese]
+		_, err := RenderDescriptor(label)
+		if err == nil || !strings.Contains(err.Error(), "no descriptor font") {
+			t.Errorf("RenderDescriptor(%q) = %v, want a missing glyph error", label, err)
+		}
+	}
+}
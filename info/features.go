@@ -18,13 +18,24 @@ type SupportedFeatures struct {
 
 func (i *Info) GetSupportedFeatures(features *gametdb.Features) {
 	for _, s := range features.Feature {
-		if strings.Contains(s, "online") {
+		switch {
+		case strings.Contains(s, "online"):
 			i.SupportedFeatures.Online = 1
 			i.SupportedFeatures.NintendoWifiConnection = 1
-		} else if s == "nintendods" {
+		case s == "wiiconnect24":
+			i.SupportedFeatures.WiiConnect24 = 1
+		case s == "nintendods" || s == "downloadplay":
 			i.SupportedFeatures.DownloadPlay = 1
-		} else if s == "download" {
+		case s == "download":
 			i.SupportedFeatures.DLC = 1
+		case s == "wirelessplay":
+			i.SupportedFeatures.WirelessPlay = 1
 		}
 	}
+
+	// Only online games report how many can play over Nintendo Wi-Fi Connection.
+	if features.OnlinePlayers != 0 && i.SupportedFeatures.Online == 0 {
+		i.SupportedFeatures.Online = 1
+		i.SupportedFeatures.NintendoWifiConnection = 1
+	}
 }
@@ -10,10 +10,6 @@ import (
 	"image"
 	"image/color"
 	"image/jpeg"
-	"io/ioutil"
-	"net/http"
-	"os"
-	"os/exec"
 )
 
 var regionToStr = map[constants.Region]string{
@@ -34,7 +30,7 @@ var consoleToImageType = map[constants.TitleType]string{
 }
 
 var consoleToTempImageType = map[constants.TitleType][]byte{
-	constants.Wii:             Placeholder3DS,
+	constants.Wii:             PlaceholderWii,
 	constants.NintendoDS:      PlaceholderDS,
 	constants.NintendoThreeDS: Placeholder3DS,
 }
@@ -48,50 +44,60 @@ var PlaceholderDS []byte
 //go:embed wii.jpg
 var PlaceholderWii []byte
 
-func (i *Info) WriteCoverArt(buffer *bytes.Buffer, titleType constants.TitleType, region constants.Region, gameID string) {
-	url := fmt.Sprintf("https://art.gametdb.com/%s/%s/%s/%s.png", titleTypeToStr[titleType], consoleToImageType[titleType], regionToStr[region], gameID)
-	resp, err := http.Get(url)
+// CoverArtURL returns where GameTDB serves the cover of a game.
+func CoverArtURL(titleType constants.TitleType, region constants.Region, gameID string) string {
+	return fmt.Sprintf("https://art.gametdb.com/%s/%s/%s/%s.png", titleTypeToStr[titleType], consoleToImageType[titleType], regionToStr[region], gameID)
+}
+
+// WriteCoverArt writes the cover of a game as a JPEG, taking it from the local art directory,
+// the cover cache or GameTDB in that order. Games without a cover get the placeholder of their console.
+func (i *Info) WriteCoverArt(buffer *bytes.Buffer, titleType constants.TitleType, region constants.Region, gameID string) error {
+	contents, ok := covers.get(titleType, region, gameID)
+	if !ok {
+		// The placeholders are already JPEGs of the right size.
+		buffer.Write(contents)
+		i.Header.PictureSize = uint32(len(contents))
+		return nil
+	}
+
+	coverImg, _, err := image.Decode(bytes.NewReader(contents))
 	if err != nil {
-		return
+		return fmt.Errorf("decoding cover art for %s: %w", gameID, err)
 	}
 
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return
-		// buffer.Write(consoleToTempImageType[titleType])
+	// Check if the image is a PNG with a transparent background.
+	_, isPNG := coverImg.(*image.NRGBA)
+	if isPNG {
+		// Handle transparent PNGs here.
+		coverImgResized := resizeImageWithAspectRatio(coverImg, 384, 384)
+		err = jpeg.Encode(buffer, coverImgResized, nil)
+		if err != nil {
+			return err
+		}
 	} else {
-		coverImg, _, err := image.Decode(resp.Body)
-		checkError(err)
-
-		// Check if the image is a PNG with a transparent background.
-		_, isPNG := coverImg.(*image.NRGBA)
-		if isPNG {
-			// Handle transparent PNGs here.
-			coverImgResized := resizeImageWithAspectRatio(coverImg, 384, 384)
-			err = jpeg.Encode(buffer, coverImgResized, nil)
-			checkError(err)
-		} else {
-			// For non-PNG images, create a new RGBA image with a white background.
-			newImage := image.NewRGBA(image.Rect(0, 0, 384, 384))
-			draw.Draw(newImage, newImage.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
-
-			// Resize the image with the new dimensions.
-			coverImgResized := resizeImageWithAspectRatio(coverImg, 384, 384)
-
-			// Calculate the offset to center the resized image on the white background.
-			offsetX := (384 - coverImgResized.Bounds().Dx()) / 2
-			offsetY := (384 - coverImgResized.Bounds().Dy()) / 2
-			offset := image.Pt(offsetX, offsetY)
-
-			// Draw the resized image onto the newImage with transparency.
-			draw.Draw(newImage, newImage.Bounds().Add(offset), coverImgResized, image.Point{}, draw.Over)
-
-			err = jpeg.Encode(buffer, newImage, nil)
-			checkError(err)
+		// For non-PNG images, create a new RGBA image with a white background.
+		newImage := image.NewRGBA(image.Rect(0, 0, 384, 384))
+		draw.Draw(newImage, newImage.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+
+		// Resize the image with the new dimensions.
+		coverImgResized := resizeImageWithAspectRatio(coverImg, 384, 384)
+
+		// Calculate the offset to center the resized image on the white background.
+		offsetX := (384 - coverImgResized.Bounds().Dx()) / 2
+		offsetY := (384 - coverImgResized.Bounds().Dy()) / 2
+		offset := image.Pt(offsetX, offsetY)
+
+		// Draw the resized image onto the newImage with transparency.
+		draw.Draw(newImage, newImage.Bounds().Add(offset), coverImgResized, image.Point{}, draw.Over)
+
+		err = jpeg.Encode(buffer, newImage, nil)
+		if err != nil {
+			return err
 		}
 	}
 
 	i.Header.PictureSize = uint32(buffer.Len())
+	return nil
 }
 
 func resizeImageWithAspectRatio(img image.Image, width, height int) image.Image {
@@ -138,64 +144,28 @@ func drawImage(dst draw.Image, src image.Image, offset image.Point) {
 	draw.Draw(dst, src.Bounds().Add(offset), src, image.Point{}, draw.Src)
 }
 
-func (i *Info) WriteDetailedRatingImage(buffer *bytes.Buffer, region constants.Region, ratingDescriptors [7]string, fileID uint32) {
-	if region == 2 {
-		for j, s := range ratingDescriptors {
-			convertedString := s // Since s is already a string, no need to convert
-			capitalized := capitalizeString(convertedString)
-
-			filename := fmt.Sprintf("%d-%d.jpg", fileID, j)
-
-			command := "convert"
-			args := []string{
-				"-size", "350x16", "xc:white",
-				"-font", "FOT-RodinNTLGPro-DB.otf",
-				"-pointsize", "14",
-				"-gravity", "West",
-				"-fill", "black",
-				"-annotate", "+1+1", capitalized,
-				"-size", "350x16", "xc:white", "+swap",
-				"-geometry", "+0+0",
-				"-composite", filename,
-			}
-
-			// Execute the command
-			cmd := exec.Command(command, args...)
-
-			// Capture the command's output and error
-			_, err := cmd.CombinedOutput()
-			if err != nil {
-				fmt.Println("Error:", err)
-				return
-			}
-
-			contents, err := ioutil.ReadFile(filename)
-			if err != nil {
-				fmt.Println("Error reading file:", err)
-				return
-			}
-
-			err = os.Remove(filename)
-
-			if err != nil {
-				fmt.Printf("Error removing the file: %v\n", err)
-				return
-			}
-
-			i.Header.DetailedRatingPictureTable[j].PictureOffset = i.GetCurrentSize(buffer)
-
-			buffer.Write(contents)
-
-			i.Header.DetailedRatingPictureTable[j].PictureSize = uint32(len(contents))
-
-			if j == 6 {
-				break
-			}
+// WriteDetailedRatingImage writes a strip for every rating descriptor of a title, labelled in language.
+func (i *Info) WriteDetailedRatingImage(buffer *bytes.Buffer, descriptors []Descriptor, language constants.Language) error {
+	for j, descriptor := range descriptors {
+		if j == MaxDescriptors {
+			break
 		}
+
+		label := descriptor.Label(language)
+		contents, err := RenderDescriptor(label)
+		if err != nil {
+			return fmt.Errorf("rendering %q: %w", label, err)
+		}
+
+		i.Header.DetailedRatingPictureTable[j].PictureOffset = i.GetCurrentSize(buffer)
+		buffer.Write(contents)
+		i.Header.DetailedRatingPictureTable[j].PictureSize = uint32(len(contents))
 	}
+
+	return nil
 }
 
-func (i *Info) WriteRatingImage(buffer *bytes.Buffer, region constants.Region) {
+func (i *Info) WriteRatingImage(buffer *bytes.Buffer, region constants.Region) error {
 	i.Header.RatingPictureOffset = i.GetCurrentSize(buffer)
 
 	regionToRatingGroup := map[constants.Region]constants.RatingGroup{
@@ -204,6 +174,18 @@ func (i *Info) WriteRatingImage(buffer *bytes.Buffer, region constants.Region) {
 		constants.PAL:   constants.PEGI,
 	}
 
-	buffer.Write(constants.ImagesSmall[regionToRatingGroup[region]][i.RatingID-8])
-	i.Header.RatingPictureSize = uint32(len(constants.ImagesSmall[regionToRatingGroup[region]][i.RatingID-8]))
+	// Titles that are unrated or waiting on a rating have no rating image.
+	if i.RatingID == constants.NoRatingID || i.RatingID == constants.RatingPendingID {
+		return nil
+	}
+
+	images := constants.ImagesSmall[regionToRatingGroup[region]]
+	if i.RatingID < constants.FirstRatingID || int(i.RatingID-constants.FirstRatingID) >= len(images) {
+		return fmt.Errorf("no rating image for rating ID %d", i.RatingID)
+	}
+
+	picture := images[i.RatingID-constants.FirstRatingID]
+	buffer.Write(picture)
+	i.Header.RatingPictureSize = uint32(len(picture))
+	return nil
 }
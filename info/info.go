@@ -1,17 +1,17 @@
 package info
 
 import (
+	"NintendoChannel/atomicfile"
 	"NintendoChannel/constants"
 	"NintendoChannel/gametdb"
+	"NintendoChannel/layout"
+	"NintendoChannel/store"
 	"bytes"
-	"context"
-	"database/sql"
 	"encoding/binary"
 	"fmt"
 	"github.com/mitchellh/go-wordwrap"
 	"hash/crc32"
-	"log"
-	"os"
+	"path/filepath"
 	"strings"
 	"unicode"
 	"unicode/utf16"
@@ -23,9 +23,9 @@ type Info struct {
 	SupportedFeatures    SupportedFeatures
 	SupportedLanguages   SupportedLanguages
 	_                    [10]byte
-	Title                [31]uint16
-	Subtitle             [31]uint16
-	ShortTitle           [31]uint16
+	Title                layout.Field
+	Subtitle             layout.Field
+	ShortTitle           layout.Field
 	DescriptionText      [3][41]uint16
 	GenreText            [29]uint16
 	PlayersText          [41]uint16
@@ -43,38 +43,15 @@ type Info struct {
 
 var timePlayed = map[string]TimePlayed{}
 
-func (i *Info) MakeInfo(fileID uint32, game *gametdb.Game, title, synopsis string, region constants.Region, language constants.Language, titleType constants.TitleType, ratingDescriptors [7]string) {
+func (i *Info) MakeInfo(fileID uint32, game *gametdb.Game, title layout.Title, synopsis string, region constants.Region, language constants.Language, titleType constants.TitleType, descriptors []Descriptor, outputDir string) error {
 	// Make other fields
 	i.GetSupportedControllers(&game.Controllers)
 	i.GetSupportedFeatures(&game.Features)
 	i.GetSupportedLanguages(game.Languages)
 
-	// Make title clean
-	if strings.Contains(title, ": ") {
-		splitTitle := strings.Split(title, ": ")
-		copy(i.Title[:], utf16.Encode([]rune(splitTitle[0])))
-		copy(i.Subtitle[:], utf16.Encode([]rune(splitTitle[1])))
-	} else if strings.Contains(title, " - ") {
-		splitTitle := strings.Split(title, " - ")
-		copy(i.Title[:], utf16.Encode([]rune(splitTitle[0])))
-		copy(i.Subtitle[:], utf16.Encode([]rune(splitTitle[1])))
-	} else if len(title) > 31 {
-		wrappedTitle := wordwrap.WrapString(title, 31)
-		for p, s := range strings.Split(wrappedTitle, "\n") {
-			switch p {
-			case 0:
-				copy(i.Title[:], utf16.Encode([]rune(s)))
-				break
-			case 1:
-				copy(i.Subtitle[:], utf16.Encode([]rune(s)))
-				break
-			default:
-				break
-			}
-		}
-	} else {
-		copy(i.Title[:], utf16.Encode([]rune(title)))
-	}
+	i.Title = title.Title
+	i.Subtitle = title.Subtitle
+	i.ShortTitle = title.ShortTitle
 
 	// Make synopsis
 	wrappedSynopsis := strings.Split(wordwrap.WrapString(strings.Replace(strings.Replace(synopsis, "\n", "", -1), "  ", " ", -1), 40), "\n")
@@ -124,67 +101,68 @@ func (i *Info) MakeInfo(fileID uint32, game *gametdb.Game, title, synopsis strin
 
 	copy(i.DisclaimerText[:], utf16.Encode([]rune("Game information is provided by GameTDB.")))
 
-	if v, ok := timePlayed[game.ID[:4]]; ok {
+	if v, ok := LookupTimePlayed(game.ID); ok {
 		i.Header.TimesPlayedTableOffset = 6744
 		i.TimePlayed = v
 	}
 
-	temp := new(bytes.Buffer)
 	imageBuffer := new(bytes.Buffer)
-	i.WriteAll(temp, imageBuffer)
-
 	i.Header.PictureOffset = i.GetCurrentSize(imageBuffer)
-	i.WriteCoverArt(imageBuffer, titleType, region, game.ID)
-	i.WriteDetailedRatingImage(imageBuffer, region, ratingDescriptors, fileID)
-	i.WriteRatingImage(imageBuffer, region)
+	err := i.WriteCoverArt(imageBuffer, titleType, region, game.ID)
+	if err != nil {
+		return fmt.Errorf("cover art: %w", err)
+	}
+
+	err = i.WriteDetailedRatingImage(imageBuffer, descriptors, language)
+	if err != nil {
+		// The info file is still usable without the descriptors.
+		fmt.Printf("Could not write rating descriptors for %s: %v\n", game.ID, err)
+	}
+
+	err = i.WriteRatingImage(imageBuffer, region)
+	if err != nil {
+		return err
+	}
+
 	i.Header.Filesize = i.GetCurrentSize(imageBuffer)
-	temp.Reset()
 
-	i.WriteAll(temp, imageBuffer)
+	temp := new(bytes.Buffer)
+	err = i.WriteAll(temp, imageBuffer)
+	if err != nil {
+		return err
+	}
+
 	crcTable := crc32.MakeTable(crc32.IEEE)
 	checksum := crc32.Checksum(temp.Bytes(), crcTable)
 	i.Header.CRC32 = checksum
 	temp.Reset()
 
-	var reg [3]string
-	reg[0] = "JP"
-	reg[1] = "GB"
-	reg[2] = "US"
-
-	var lang [7]string
-	lang[0] = "ja"
-	lang[1] = "en"
-	lang[2] = "de"
-	lang[3] = "fr"
-	lang[4] = "es"
-	lang[5] = "it"
-	lang[6] = "nl"
-
-	i.WriteAll(temp, imageBuffer)
-
-	err := os.MkdirAll(fmt.Sprintf("./infos/%d/%d/", region, language), 0755)
-	checkError(err)
-	err = os.WriteFile(fmt.Sprintf("./infos/%d/%d/%d.info", region, language, fileID), temp.Bytes(), 0666)
-	checkError(err)
-}
-
-func checkError(err error) {
+	err = i.WriteAll(temp, imageBuffer)
 	if err != nil {
-		log.Fatalf("Nintendo Channel info file generator has encountered a fatal error! Reason: %v\n", err)
+		return err
 	}
+
+	// A failed write must not damage the info file of an earlier run, which the list keeps using.
+	return atomicfile.WriteFile(Path(outputDir, region, language, fileID), temp.Bytes())
+}
+
+// Path returns where the info file for a title is written.
+func Path(outputDir string, region constants.Region, language constants.Language, fileID uint32) string {
+	return filepath.Join(outputDir, fmt.Sprintf("infos/%d/%d/%d.info", region, language, fileID))
 }
 
-func (i *Info) WriteAll(buffer, imageBuffer *bytes.Buffer) {
+func (i *Info) WriteAll(buffer, imageBuffer *bytes.Buffer) error {
 	err := binary.Write(buffer, binary.BigEndian, *i)
-	checkError(err)
+	if err != nil {
+		return err
+	}
 
 	buffer.Write(imageBuffer.Bytes())
+	return nil
 }
 
-func (i *Info) GetCurrentSize(_buffer *bytes.Buffer) uint32 {
-	buffer := bytes.NewBuffer(nil)
-	i.WriteAll(buffer, _buffer)
-	return uint32(buffer.Len())
+func (i *Info) GetCurrentSize(imageBuffer *bytes.Buffer) uint32 {
+	return uint32(binary.Size(*i) + imageBuffer.Len())
 }
 
 func capitalizeString(input string) string {
@@ -199,7 +177,9 @@ func capitalizeString(input string) string {
 				capitalizedWords = append(capitalizedWords, word)
 			} else {
 				// Capitalize the first letter of the word
-				capitalizedWord := string(unicode.ToUpper(rune(word[0]))) + strings.ToLower(word[1:])
+				runes := []rune(strings.ToLower(word))
+				runes[0] = unicode.ToUpper(runes[0])
+				capitalizedWord := string(runes)
 				capitalizedWords = append(capitalizedWords, capitalizedWord)
 			}
 		}
@@ -208,24 +188,34 @@ func capitalizeString(input string) string {
 	return strings.Join(capitalizedWords, " ")
 }
 
-func GetTimePlayed(ctx context.Context, pool *sql.DB) {
-	rows, err := pool.Query(`SELECT game_id, COUNT(game_id), SUM(times_played), SUM(time_played) FROM time_played GROUP BY game_id`)
-	checkError(err)
-
-	for rows.Next() {
-		var gameID string
-		var numberOfPlayers int
-		var totalTimesPlayed int
-		var totalTimePlayed int
+func GetTimePlayed(s store.Store) error {
+	rows, err := s.TimePlayed()
+	if err != nil {
+		return fmt.Errorf("querying time played: %w", err)
+	}
 
-		err = rows.Scan(&gameID, &numberOfPlayers, &totalTimesPlayed, &totalTimePlayed)
-		checkError(err)
+	for _, row := range rows {
+		if row.NumberOfPlayers == 0 {
+			continue
+		}
 
-		timePlayed[gameID] = TimePlayed{
-			TotalTimePlayed:           uint32(totalTimePlayed / 60),
-			TimeSpentPlayingPerPerson: uint32(totalTimePlayed / numberOfPlayers),
-			TotalTimesPlayed:          uint32(totalTimesPlayed),
-			TimesPlayedPerPerson:      uint32((float64(totalTimesPlayed / numberOfPlayers)) / 0.01),
+		timePlayed[row.GameID] = TimePlayed{
+			TotalTimePlayed:           uint32(row.TimePlayed / 60),
+			TimeSpentPlayingPerPerson: uint32(row.TimePlayed / row.NumberOfPlayers),
+			TotalTimesPlayed:          uint32(row.TimesPlayed),
+			TimesPlayedPerPerson:      uint32((float64(row.TimesPlayed / row.NumberOfPlayers)) / 0.01),
 		}
 	}
+
+	return nil
+}
+
+// LookupTimePlayed returns the play time written to the info file of the game with gameID.
+func LookupTimePlayed(gameID string) (TimePlayed, bool) {
+	if len(gameID) > 4 {
+		gameID = gameID[:4]
+	}
+
+	v, ok := timePlayed[gameID]
+	return v, ok
 }
@@ -0,0 +1,161 @@
+// Package layout fits title text into the fixed size UTF-16 fields of dllist.bin and info files.
+package layout
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// FieldLength is the size in UTF-16 code units of the title fields. Text is cut to one less
+// so the field always ends with a null terminator.
+const FieldLength = 31
+
+const maxUnits = FieldLength - 1
+
+// Field is a fixed size UTF-16 title field.
+type Field [FieldLength]uint16
+
+// Title is the title of a game laid out over the three title fields.
+type Title struct {
+	Title      Field
+	Subtitle   Field
+	ShortTitle Field
+}
+
+// separators split a title from its subtitle, in order of preference.
+var separators = []string{": ", " - ", "：", " – "}
+
+// noBreakBefore are characters a line may not start with, such as closing brackets
+// and the prolonged sound mark.
+const noBreakBefore = "、。，．・：；？！ー）」』】〕〉》”’ゝゞヽヾぁぃぅぇぉっゃゅょゎァィゥェォッャュョヮヵヶ!),.:;?]}"
+
+// noBreakAfter are characters a line may not end with, such as opening brackets.
+const noBreakAfter = "（「『【〔〈《“‘([{"
+
+// Units returns the length of s in UTF-16 code units.
+func Units(s string) int {
+	units := 0
+	for _, r := range s {
+		units += utf16.RuneLen(r)
+	}
+
+	return units
+}
+
+// Truncate cuts s to at most units UTF-16 code units without splitting a surrogate pair.
+func Truncate(s string, units int) string {
+	for i, r := range s {
+		units -= utf16.RuneLen(r)
+		if units < 0 {
+			return s[:i]
+		}
+	}
+
+	return s
+}
+
+// Encode converts s to a field, truncating it to fit.
+func Encode(s string) Field {
+	var field Field
+	copy(field[:], utf16.Encode([]rune(Truncate(s, maxUnits))))
+	return field
+}
+
+// Make lays title out over the title fields. A title too long for one field is split at a
+// subtitle separator if there is one, or wrapped onto the subtitle otherwise.
+// Any field set in override replaces the generated one.
+func Make(title string, override Override) Title {
+	title = strings.TrimSpace(title)
+	main, subtitle := Split(title)
+
+	short := main
+	if override.Title != "" {
+		main = override.Title
+		short = override.Title
+	}
+
+	if override.Subtitle != "" {
+		subtitle = override.Subtitle
+	}
+
+	if override.ShortTitle != "" {
+		short = override.ShortTitle
+	}
+
+	return Title{
+		Title:      Encode(main),
+		Subtitle:   Encode(subtitle),
+		ShortTitle: Encode(wrap(shortTitle(short))),
+	}
+}
+
+// Split returns the title and subtitle lines of title.
+func Split(title string) (string, string) {
+	title = strings.TrimSpace(title)
+	if Units(title) <= maxUnits {
+		return title, ""
+	}
+
+	for _, separator := range separators {
+		if i := strings.Index(title, separator); i > 0 && Units(title[:i]) <= maxUnits {
+			return strings.TrimSpace(title[:i]), strings.TrimSpace(title[i+len(separator):])
+		}
+	}
+
+	line := wrap(title)
+	return line, strings.TrimSpace(title[len(line):])
+}
+
+// shortTitle returns the part of title before any subtitle separator.
+func shortTitle(title string) string {
+	for _, separator := range separators {
+		if i := strings.Index(title, separator); i > 0 {
+			return strings.TrimSpace(title[:i])
+		}
+	}
+
+	return title
+}
+
+// wrap returns the longest start of s that fits in a field and ends at a line break opportunity:
+// a space, or a boundary next to a CJK character. Text with no such opportunity is cut.
+func wrap(s string) string {
+	if Units(s) <= maxUnits {
+		return s
+	}
+
+	runes := []rune(s)
+	best := -1
+	units := 0
+	for i, r := range runes {
+		units += utf16.RuneLen(r)
+		if units > maxUnits {
+			break
+		}
+
+		if i+1 < len(runes) && canBreak(r, runes[i+1]) {
+			best = i + 1
+		}
+	}
+
+	if best == -1 {
+		return Truncate(s, maxUnits)
+	}
+
+	return strings.TrimSpace(string(runes[:best]))
+}
+
+// canBreak reports whether a line may be broken between before and after.
+func canBreak(before, after rune) bool {
+	if strings.ContainsRune(noBreakBefore, after) || strings.ContainsRune(noBreakAfter, before) {
+		return false
+	}
+
+	return unicode.IsSpace(after) || unicode.IsSpace(before) || isCJK(before) || isCJK(after)
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303F) || (r >= 0xFF00 && r <= 0xFFEF)
+}
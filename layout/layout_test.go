@@ -0,0 +1,136 @@
+package layout
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestUnits(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		units int
+	}{
+		{"Wii Sports", 10},
+		{"ゼルダの伝説", 6},
+		// Characters outside the Basic Multilingual Plane take a surrogate pair.
+		{"😀", 2},
+		{"a😀b", 4},
+		{"𠮷野家", 4},
+	} {
+		if units := Units(test.s); units != test.units {
+			t.Errorf("Units(%q) = %d, want %d", test.s, units, test.units)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		units int
+		want  string
+	}{
+		{"Wii Sports", 3, "Wii"},
+		{"😀😀", 4, "😀😀"},
+		// A surrogate pair that does not fit is dropped whole.
+		{"😀😀", 3, "😀"},
+		{"a😀", 2, "a"},
+		{"𠮷野家", 1, ""},
+	} {
+		if got := Truncate(test.s, test.units); got != test.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", test.s, test.units, got, test.want)
+		}
+	}
+}
+
+func TestEncodeSurrogatePairs(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		want string
+	}{
+		{strings.Repeat("😀", 15), strings.Repeat("😀", 15)},
+		{strings.Repeat("😀", 16), strings.Repeat("😀", 15)},
+		// The high surrogate of the last character would be the 30th unit, so it is left out with its pair.
+		{strings.Repeat("a", 29) + "😀", strings.Repeat("a", 29)},
+		{strings.Repeat("𠮷", 20), strings.Repeat("𠮷", 15)},
+	} {
+		field := Encode(test.s)
+		if field[FieldLength-1] != 0 {
+			t.Errorf("Encode(%q) does not end with a null terminator", test.s)
+		}
+
+		end := 0
+		for end < FieldLength && field[end] != 0 {
+			end++
+		}
+
+		if got := string(utf16.Decode(field[:end])); got != test.want {
+			t.Errorf("Encode(%q) holds %q, want %q", test.s, got, test.want)
+		}
+	}
+}
+
+func TestSplitJapanese(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		title          string
+		line, subtitle string
+	}{
+		{
+			name:     "fits",
+			title:    "ぷよぷよフィーバーチャレンジスペシャルエディションパーティー",
+			line:     "ぷよぷよフィーバーチャレンジスペシャルエディションパーティー",
+			subtitle: "",
+		},
+		{
+			name:     "separator",
+			title:    "ゼルダの伝説：トワイライトプリンセスとても長いサブタイトルがここに入ります",
+			line:     "ゼルダの伝説",
+			subtitle: "トワイライトプリンセスとても長いサブタイトルがここに入ります",
+		},
+		{
+			name:     "between characters",
+			title:    "ポケモン不思議のダンジョン　空の探検隊とっても長いタイトルのゲームです",
+			line:     "ポケモン不思議のダンジョン　空の探検隊とっても長いタイトルの",
+			subtitle: "ゲームです",
+		},
+		{
+			// A line may not start with the prolonged sound mark.
+			name:     "prolonged sound mark",
+			title:    strings.Repeat("あ", 29) + "ーーい",
+			line:     strings.Repeat("あ", 28),
+			subtitle: "あーーい",
+		},
+		{
+			// A line may not end with an opening bracket.
+			name:     "opening bracket",
+			title:    strings.Repeat("あ", 29) + "「い」",
+			line:     strings.Repeat("あ", 29),
+			subtitle: "「い」",
+		},
+		{
+			// A character taking a surrogate pair that does not fit moves to the next line whole.
+			name:     "surrogate pair at the end of the line",
+			title:    strings.Repeat("あ", 29) + "𠮷い",
+			line:     strings.Repeat("あ", 29),
+			subtitle: "𠮷い",
+		},
+		{
+			name:     "surrogate pairs",
+			title:    strings.Repeat("𠮷", 20),
+			line:     strings.Repeat("𠮷", 15),
+			subtitle: strings.Repeat("𠮷", 5),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			line, subtitle := Split(test.title)
+			if line != test.line || subtitle != test.subtitle {
+				t.Errorf("Split(%q) = %q, %q, want %q, %q", test.title, line, subtitle, test.line, test.subtitle)
+			}
+
+			if Units(line) > maxUnits {
+				t.Errorf("line %q is %d units, fields hold %d", line, Units(line), maxUnits)
+			}
+		})
+	}
+}
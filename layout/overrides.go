@@ -0,0 +1,48 @@
+package layout
+
+import (
+	"NintendoChannel/constants"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// Override replaces the generated title fields of a game. Empty fields are generated as usual.
+type Override struct {
+	Title      string `json:"title"`
+	Subtitle   string `json:"subtitle"`
+	ShortTitle string `json:"short_title"`
+}
+
+// Overrides maps a game ID such as "RMCE01", or a game ID and language such as "RMCE01/de",
+// to the override of its title. The game ID and language takes precedence.
+type Overrides map[string]Override
+
+// LoadOverrides reads the overrides stored at path. A missing file holds no overrides.
+func LoadOverrides(path string) (Overrides, error) {
+	overrides := Overrides{}
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return overrides, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(contents, &overrides)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return overrides, nil
+}
+
+// Get returns the override of the game with id in language.
+func (o Overrides) Get(id string, language constants.Language) Override {
+	if override, ok := o[id+"/"+language.String()]; ok {
+		return override
+	}
+
+	return o[id]
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"NintendoChannel/constants"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// jsonStore is a Store read from a single JSON file, for running the generator offline.
+// The file mirrors our database tables:
+//
+//	{
+//		"videos": [{"id": 1, "length": 120, "video_type": 1, "names": {"en": "..."}, "date_added": "2023-01-01T00:00:00Z"}],
+//		"recommendations": {"RMCE": 20},
+//		"time_played": [{"game_id": "RMCE", "number_of_players": 2, "times_played": 10, "time_played": 600}],
+//		"survey": [{"game_id": "RMCE", "group": 0, "responses": 4, "hardcore": 1, "gamers": 2, "with_friends": 3}]
+//	}
+type jsonStore struct {
+	VideoEntries        []jsonVideo    `json:"videos"`
+	RecommendationCount map[string]int `json:"recommendations"`
+	TimePlayedEntries   []TimePlayed   `json:"time_played"`
+	SurveyEntries       []Survey       `json:"survey"`
+}
+
+type jsonVideo struct {
+	ID     uint32 `json:"id"`
+	Length uint16 `json:"length"`
+	Type   uint8  `json:"video_type"`
+	// Names maps a language code such as "en" to the video's title in that language.
+	// As in the database, a literal \n in a title is a line break.
+	Names     map[string]string `json:"names"`
+	DateAdded time.Time         `json:"date_added"`
+}
+
+func openJSON(path string) (*jsonStore, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s jsonStore
+	err = json.Unmarshal(contents, &s)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return &s, nil
+}
+
+func toVideos(entries []jsonVideo, language constants.Language) []Video {
+	var videos []Video
+	for _, video := range entries {
+		videos = append(videos, Video{
+			ID:     video.ID,
+			Title:  strings.Replace(video.Names[language.String()], "\\n", "\n", -1),
+			Length: video.Length,
+			Type:   video.Type,
+		})
+	}
+
+	return videos
+}
+
+func (s *jsonStore) Videos(language constants.Language) ([]Video, error) {
+	entries := make([]jsonVideo, len(s.VideoEntries))
+	copy(entries, s.VideoEntries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DateAdded.After(entries[j].DateAdded)
+	})
+
+	return toVideos(entries, language), nil
+}
+
+func (s *jsonStore) PopularVideos(language constants.Language) ([]Video, error) {
+	videos := toVideos(s.VideoEntries, language)
+	rand.Shuffle(len(videos), func(i, j int) {
+		videos[i], videos[j] = videos[j], videos[i]
+	})
+
+	return videos, nil
+}
+
+func (s *jsonStore) Recommendations(region constants.Region) (map[string]int, error) {
+	recommendations := map[string]int{}
+	for gameID, count := range s.RecommendationCount {
+		if IsForRegion(gameID, region) {
+			recommendations[gameID] = count
+		}
+	}
+
+	return recommendations, nil
+}
+
+func (s *jsonStore) TimePlayed() ([]TimePlayed, error) {
+	return s.TimePlayedEntries, nil
+}
+
+func (s *jsonStore) Survey() ([]Survey, error) {
+	return s.SurveyEntries, nil
+}
+
+func (s *jsonStore) Close() error {
+	return nil
+}
@@ -0,0 +1,209 @@
+package store
+
+import (
+	"NintendoChannel/config"
+	"NintendoChannel/constants"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgconn"
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"net/url"
+	"strings"
+)
+
+// dialect holds the parts of our queries that differ between databases.
+type dialect struct {
+	random string
+	dsn    func(db config.Database) string
+	// missingTable reports whether a query failed because a table it reads does not exist.
+	missingTable func(err error) bool
+}
+
+var mysqlDialect = dialect{
+	random: "RAND()",
+	dsn: func(db config.Database) string {
+		return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", db.User, db.Password, db.Host, db.Port, db.Name)
+	},
+	missingTable: func(err error) bool {
+		// ER_NO_SUCH_TABLE
+		var mysqlErr *mysql.MySQLError
+		return errors.As(err, &mysqlErr) && mysqlErr.Number == 1146
+	},
+}
+
+var postgresDialect = dialect{
+	random: "RANDOM()",
+	dsn: func(db config.Database) string {
+		dsn := url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(db.User, db.Password),
+			Host:   fmt.Sprintf("%s:%d", db.Host, db.Port),
+			Path:   db.Name,
+		}
+
+		return dsn.String()
+	},
+	missingTable: func(err error) bool {
+		// undefined_table
+		var pgErr *pgconn.PgError
+		return errors.As(err, &pgErr) && pgErr.Code == "42P01"
+	},
+}
+
+var videoNameColumns = map[constants.Language]string{
+	constants.Japanese: "name_japanese",
+	constants.English:  "name_english",
+	constants.German:   "name_german",
+	constants.French:   "name_french",
+	constants.Spanish:  "name_spanish",
+	constants.Italian:  "name_italian",
+	constants.Dutch:    "name_dutch",
+}
+
+const (
+	QueryVideos          = `SELECT id, %s, length, video_type FROM videos ORDER BY date_added DESC`
+	QueryPopularVideos   = `SELECT id, %s, length, video_type FROM videos ORDER BY %s DESC`
+	QueryRecommendations = `SELECT COUNT(game_id), game_id FROM recommendations GROUP BY game_id`
+	QueryTimePlayed      = `SELECT game_id, COUNT(game_id), SUM(times_played), SUM(time_played) FROM time_played GROUP BY game_id`
+	QuerySurvey          = `SELECT game_id, audience_group, COUNT(*), SUM(hardcore), SUM(gamer), SUM(with_friends) FROM survey_responses GROUP BY game_id, audience_group`
+)
+
+// sqlStore is a Store backed by a MySQL or PostgreSQL database.
+type sqlStore struct {
+	pool    *sql.DB
+	dialect dialect
+}
+
+func openSQL(driver string, d dialect, db config.Database) (*sqlStore, error) {
+	pool, err := sql.Open(driver, d.dsn(db))
+	if err != nil {
+		return nil, err
+	}
+
+	pool.SetMaxOpenConns(db.MaxOpenConns)
+	pool.SetMaxIdleConns(db.MaxIdleConns)
+
+	// Ensure this connection is valid.
+	err = pool.Ping()
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return &sqlStore{pool: pool, dialect: d}, nil
+}
+
+func (s *sqlStore) Videos(language constants.Language) ([]Video, error) {
+	column, ok := videoNameColumns[language]
+	if !ok {
+		return nil, fmt.Errorf("no video names for language %d", language)
+	}
+
+	return s.queryVideos(fmt.Sprintf(QueryVideos, column))
+}
+
+func (s *sqlStore) PopularVideos(language constants.Language) ([]Video, error) {
+	column, ok := videoNameColumns[language]
+	if !ok {
+		return nil, fmt.Errorf("no video names for language %d", language)
+	}
+
+	return s.queryVideos(fmt.Sprintf(QueryPopularVideos, column, s.dialect.random))
+}
+
+func (s *sqlStore) queryVideos(query string) ([]Video, error) {
+	rows, err := s.pool.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var videos []Video
+	for rows.Next() {
+		var video Video
+		err = rows.Scan(&video.ID, &video.Title, &video.Length, &video.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		video.Title = strings.Replace(video.Title, "\\n", "\n", -1)
+		videos = append(videos, video)
+	}
+
+	return videos, rows.Err()
+}
+
+func (s *sqlStore) Recommendations(region constants.Region) (map[string]int, error) {
+	rows, err := s.pool.Query(QueryRecommendations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recommendations := map[string]int{}
+	for rows.Next() {
+		var gameID string
+		var count int
+		err = rows.Scan(&count, &gameID)
+		if err != nil {
+			return nil, err
+		}
+
+		if IsForRegion(gameID, region) {
+			recommendations[gameID] = count
+		}
+	}
+
+	return recommendations, rows.Err()
+}
+
+func (s *sqlStore) TimePlayed() ([]TimePlayed, error) {
+	rows, err := s.pool.Query(QueryTimePlayed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var timePlayed []TimePlayed
+	for rows.Next() {
+		var played TimePlayed
+		err = rows.Scan(&played.GameID, &played.NumberOfPlayers, &played.TimesPlayed, &played.TimePlayed)
+		if err != nil {
+			return nil, err
+		}
+
+		timePlayed = append(timePlayed, played)
+	}
+
+	return timePlayed, rows.Err()
+}
+
+// Survey returns no answers if the database has no survey_responses table, as surveys are optional.
+func (s *sqlStore) Survey() ([]Survey, error) {
+	rows, err := s.pool.Query(QuerySurvey)
+	if err != nil && s.dialect.missingTable(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var surveys []Survey
+	for rows.Next() {
+		var survey Survey
+		err = rows.Scan(&survey.GameID, &survey.Group, &survey.Responses, &survey.Hardcore, &survey.Gamers, &survey.WithFriends)
+		if err != nil {
+			return nil, err
+		}
+
+		surveys = append(surveys, survey)
+	}
+
+	return surveys, rows.Err()
+}
+
+func (s *sqlStore) Close() error {
+	return s.pool.Close()
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"NintendoChannel/config"
+	"NintendoChannel/constants"
+	"fmt"
+)
+
+// Store is a source of the data the generators need besides GameTDB.
+type Store interface {
+	// Videos returns every video, newest first, titled in the given language.
+	Videos(language constants.Language) ([]Video, error)
+	// PopularVideos returns the videos to feature as popular, titled in the given language.
+	PopularVideos(language constants.Language) ([]Video, error)
+	// Recommendations returns the number of recommendations per game ID for titles sold in region.
+	Recommendations(region constants.Region) (map[string]int, error)
+	// TimePlayed returns the aggregated play time of every game.
+	TimePlayed() ([]TimePlayed, error)
+	// Survey returns the survey answers of every game, summed per audience group.
+	// A store without survey answers returns none, and titles then get the default audience.
+	Survey() ([]Survey, error)
+	Close() error
+}
+
+// Video is a video that can be watched on the channel.
+type Video struct {
+	ID     uint32
+	Title  string
+	Length uint16
+	Type   uint8
+}
+
+// TimePlayed is the play time reported for a game, summed across every player.
+type TimePlayed struct {
+	GameID          string `json:"game_id"`
+	NumberOfPlayers int    `json:"number_of_players"`
+	TimesPlayed     int    `json:"times_played"`
+	TimePlayed      int    `json:"time_played"`
+}
+
+// Survey is how players in one audience group described a game.
+// Hardcore, Gamers and WithFriends count the players answering yes to each question.
+type Survey struct {
+	GameID string `json:"game_id"`
+	// Group is the age and gender group of the players, from 0 to 11.
+	Group       int `json:"group"`
+	Responses   int `json:"responses"`
+	Hardcore    int `json:"hardcore"`
+	Gamers      int `json:"gamers"`
+	WithFriends int `json:"with_friends"`
+}
+
+// Open opens the Store described by the database configuration.
+func Open(db config.Database) (Store, error) {
+	switch db.Driver {
+	case "", "mysql":
+		return openSQL("mysql", mysqlDialect, db)
+	case "postgres":
+		return openSQL("pgx", postgresDialect, db)
+	case "json":
+		return openJSON(db.Path)
+	default:
+		return nil, fmt.Errorf("unknown database driver %q", db.Driver)
+	}
+}
+
+// IsForRegion reports whether a game with this ID is sold in region.
+func IsForRegion(gameID string, region constants.Region) bool {
+	if len(gameID) < 4 {
+		return false
+	}
+
+	// First see if this game could exist in all regions
+	suffix := gameID[3:]
+	switch suffix {
+	case "A", "B", "U", "X":
+		return true
+	}
+
+	// Now determine if the game exists for this region
+	switch region {
+	case constants.NTSC:
+		return suffix == "E" || suffix == "N"
+	case constants.Japan:
+		return suffix == "J"
+	case constants.PAL:
+		return suffix == "P" || suffix == "L" || suffix == "M"
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+package thumbnail
+
+import (
+	"NintendoChannel/atomicfile"
+	"NintendoChannel/config"
+	"NintendoChannel/constants"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/disintegration/imaging"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// sourceExtensions are the image types a thumbnail can be made from, in order of preference.
+// .img files are thumbnails that are already encoded, which are used as they are.
+var sourceExtensions = []string{".img", ".png", ".jpg", ".jpeg"}
+
+// images builds the thumbnail of every video from its source image.
+type images struct {
+	config config.Thumbnails
+	// placeholder is shown for videos without a source image. It is only drawn once it is needed.
+	placeholder []byte
+}
+
+// source returns the path of the source image of a video, or an empty path if it has none.
+// An image in <region>/<language>/ takes precedence over one shared by every list.
+func (i *images) source(region constants.Region, language constants.Language, videoID uint32) (string, error) {
+	for _, dir := range []string{filepath.Join(i.config.ImageDir, region.String(), language.String()), i.config.ImageDir} {
+		for _, extension := range sourceExtensions {
+			path := filepath.Join(dir, fmt.Sprintf("%d%s", videoID, extension))
+			_, err := os.Stat(path)
+			if err == nil {
+				return path, nil
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return "", err
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// get returns the thumbnail of a video, and whether it was made from a source image rather than the placeholder.
+func (i *images) get(region constants.Region, language constants.Language, videoID uint32) ([]byte, bool, error) {
+	path, err := i.source(region, language, videoID)
+	if err != nil {
+		return nil, false, err
+	} else if path == "" {
+		placeholder, err := i.getPlaceholder()
+		return placeholder, false, err
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if filepath.Ext(path) == ".img" {
+		return contents, true, nil
+	}
+
+	thumbnail, err := i.convert(contents)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return thumbnail, true, nil
+}
+
+// convert encodes a source image, reusing the result of an earlier run if the image and settings are unchanged.
+func (i *images) convert(contents []byte) ([]byte, error) {
+	hash := sha256.New()
+	hash.Write(contents)
+	fmt.Fprintf(hash, "%dx%d@%d", i.config.Width, i.config.Height, i.config.Quality)
+	cachePath := filepath.Join(i.config.CacheDir, hex.EncodeToString(hash.Sum(nil))+".img")
+
+	if i.config.CacheDir != "" {
+		cached, err := os.ReadFile(cachePath)
+		if err == nil {
+			return cached, nil
+		}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(contents))
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail, err := i.encode(fit(img, i.config.Width, i.config.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	if i.config.CacheDir != "" {
+		err = atomicfile.WriteFile(cachePath, thumbnail)
+		if err != nil {
+			return nil, fmt.Errorf("caching thumbnail: %w", err)
+		}
+	}
+
+	return thumbnail, nil
+}
+
+// fit scales an image to fit width and height, letterboxing it with black bars like the video it is taken from.
+func fit(img image.Image, width, height int) image.Image {
+	resized := imaging.Fit(img, width, height, imaging.Lanczos)
+
+	result := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(result, result.Bounds(), &image.Uniform{C: color.Black}, image.Point{}, draw.Src)
+
+	offset := image.Pt((width-resized.Bounds().Dx())/2, (height-resized.Bounds().Dy())/2)
+	draw.Draw(result, resized.Bounds().Add(offset), resized, image.Point{}, draw.Over)
+	return result
+}
+
+// encode encodes a thumbnail as the baseline JPEG the channel reads.
+func (i *images) encode(img image.Image) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	err := jpeg.Encode(buffer, img, &jpeg.Options{Quality: i.config.Quality})
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (i *images) getPlaceholder() ([]byte, error) {
+	if i.placeholder != nil {
+		return i.placeholder, nil
+	}
+
+	placeholder, err := i.encode(drawPlaceholder(i.config.Width, i.config.Height))
+	if err != nil {
+		return nil, err
+	}
+
+	i.placeholder = placeholder
+	return placeholder, nil
+}
+
+// drawPlaceholder draws a grey frame with a play symbol in its centre.
+func drawPlaceholder(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.RGBA{0x40, 0x40, 0x40, 0xFF}}, image.Point{}, draw.Src)
+
+	size := height / 3
+	if width < height {
+		size = width / 3
+	}
+
+	// The triangle points right, with its left edge size pixels tall.
+	left, top := (width-size)/2, (height-size)/2
+	for x := 0; x < size; x++ {
+		// Each column is shorter than the last, reaching a point at the right edge.
+		inset := x / 2
+		for y := inset; y < size-inset; y++ {
+			img.Set(left+x, top+y, color.RGBA{0xD0, 0xD0, 0xD0, 0xFF})
+		}
+	}
+
+	return img
+}
@@ -1,16 +1,15 @@
 package thumbnail
 
 import (
+	"NintendoChannel/config"
 	"NintendoChannel/constants"
-	"bufio"
+	"NintendoChannel/store"
 	"bytes"
-	"database/sql"
-	_ "embed"
 	"encoding/binary"
 	"fmt"
-	_ "github.com/go-sql-driver/mysql"
-	"log"
+	"hash/crc32"
 	"os"
+	"path/filepath"
 )
 
 type Thumbnail struct {
@@ -21,7 +20,7 @@ type Thumbnail struct {
 	Unknown1       uint32
 	LanguageCode   uint32
 	CountryCode    uint32
-	Unknown2       uint32
+	ThumbnailID    uint32
 	Unknown3       uint32
 	NumberOfImages uint32
 }
@@ -33,52 +32,88 @@ type ImageTable struct {
 
 const ThumbnailHeaderSize = 32
 
-func checkError(err error) {
-	if err != nil {
-		log.Fatalf("Nintendo Channel file generator has encountered a fatal error! Reason: %v\n", err)
-	}
+// Path returns where the thumbnail.bin for a region and language is written.
+func Path(outputDir string, region constants.Region, language constants.Language) string {
+	return filepath.Join(outputDir, fmt.Sprintf("thumbnails/%d/%d/thumbnail.bin", region, language))
 }
 
-func WriteThumbnail() {
-	file, err := os.Open("sql.txt")
+// ReadID returns the ThumbnailID of the thumbnail.bin at path.
+func ReadID(path string) (uint32, error) {
+	file, err := os.Open(path)
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
 	defer file.Close()
 
-	// Read the password from the file
-	scanner := bufio.NewScanner(file)
-	scanner.Scan()
-	password := scanner.Text()
-
-	// Check for errors while scanning
-	if err := scanner.Err(); err != nil {
-		panic(err)
+	var header Thumbnail
+	err = binary.Read(file, binary.BigEndian, &header)
+	if err != nil {
+		return 0, fmt.Errorf("reading %s: %w", path, err)
 	}
 
+	return header.ThumbnailID, nil
+}
+
+// WriteThumbnails writes a thumbnail.bin for every region and language in regions.
+// The thumbnail of each video is made from its image in Thumbnails.ImageDir, or is a placeholder if it has none.
+func WriteThumbnails(cfg *config.Config, regions []constants.RegionMeta) error {
 	// Initialize database
-	pool, err := sql.Open("mysql", fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", "rc24", password, "127.0.0.1", 3306, "rc24_nc"))
+	s, err := store.Open(cfg.Database)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	defer s.Close()
+
+	sources := &images{config: cfg.Thumbnails}
+	for _, region := range regions {
+		for _, language := range region.Languages {
+			videos, err := s.Videos(language)
+			if err != nil {
+				return fmt.Errorf("querying videos: %w", err)
+			}
+
+			var thumbnails [][]byte
+			placeholders := 0
+			for _, video := range videos {
+				thumbnail, found, err := sources.get(region.Region, language, video.ID)
+				if err != nil {
+					return fmt.Errorf("thumbnail for video %d: %w", video.ID, err)
+				}
+
+				if !found {
+					fmt.Printf("No image for video %d, using the placeholder - Region: %s, Language: %s\n", video.ID, region.Region, language)
+					placeholders++
+				}
+
+				thumbnails = append(thumbnails, thumbnail)
+			}
+
+			contents, err := makeThumbnail(region, language, thumbnails)
+			if err != nil {
+				return err
+			}
+
+			path := Path(cfg.OutputDir, region.Region, language)
+			err = os.MkdirAll(filepath.Dir(path), 0755)
+			if err != nil {
+				return err
+			}
+
+			err = os.WriteFile(path, contents, 0666)
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Wrote %s - Region: %s, Language: %s, Videos: %d, Placeholders: %d\n", path, region.Region, language, len(thumbnails), placeholders)
+		}
 	}
 
-	// Ensure this Postgresql connection is valid.
-
-	rows, err := pool.Query(constants.GetVideoQueryString(constants.English))
-	checkError(err)
-
-	var images []int
-	for rows.Next() {
-		var id int
-		var queriedTitle string
-		var length int
-		var videoType int
-
-		err = rows.Scan(&id, &queriedTitle, &length, &videoType)
-		checkError(err)
+	return nil
+}
 
-		images = append(images, id)
-	}
+func makeThumbnail(region constants.RegionMeta, language constants.Language, images [][]byte) ([]byte, error) {
+	// Every image is written twice because yes
+	images = append(images, images...)
 
 	buffer := new(bytes.Buffer)
 	imageBuffer := new(bytes.Buffer)
@@ -87,64 +122,58 @@ func WriteThumbnail() {
 		Unknown:        2,
 		Filesize:       0,
 		Unknown1:       601820255,
-		LanguageCode:   1,
-		CountryCode:    49,
-		Unknown2:       1,
+		LanguageCode:   uint32(language),
+		CountryCode:    region.CountryCode,
+		ThumbnailID:    thumbnailID(images),
 		Unknown3:       1252951207,
-		NumberOfImages: uint32(len(images) * 2),
+		NumberOfImages: uint32(len(images)),
 	}
 
-	err = binary.Write(buffer, binary.BigEndian, header)
-	checkError(err)
+	err := binary.Write(buffer, binary.BigEndian, header)
+	if err != nil {
+		return nil, err
+	}
 
 	deadBeef := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+	imagesOffset := ThumbnailHeaderSize + 8*len(images)
 
 	for _, image := range images {
-		file, err := os.ReadFile(fmt.Sprintf("./movie/US/en/%d.img", image))
-		checkError(err)
-
 		table := ImageTable{
-			ImageSize:   uint32(len(file)),
-			ImageOffset: uint32((ThumbnailHeaderSize + 8*(len(images)*2)) + imageBuffer.Len()),
+			ImageSize:   uint32(len(image)),
+			ImageOffset: uint32(imagesOffset + imageBuffer.Len()),
 		}
 
 		err = binary.Write(buffer, binary.BigEndian, table)
-		checkError(err)
+		if err != nil {
+			return nil, err
+		}
 
-		imageBuffer.Write(file)
+		imageBuffer.Write(image)
 
 		counter := 0
-		for (256+imageBuffer.Len())%32 != 0 {
+		for (imagesOffset+imageBuffer.Len())%32 != 0 {
 			imageBuffer.WriteByte(deadBeef[counter%4])
 			counter++
 		}
 	}
 
-	// Write twice because yes
-	for _, image := range images {
-		file, err := os.ReadFile(fmt.Sprintf("./movie/US/en/%d.img", image))
-		checkError(err)
-
-		table := ImageTable{
-			ImageSize:   uint32(len(file)),
-			ImageOffset: uint32((ThumbnailHeaderSize + 8*(len(images)*2)) + imageBuffer.Len()),
-		}
-
-		err = binary.Write(buffer, binary.BigEndian, table)
-		checkError(err)
+	buffer.Write(imageBuffer.Bytes())
+	binary.BigEndian.PutUint32(buffer.Bytes()[4:8], uint32(buffer.Len()))
 
-		imageBuffer.Write(file)
+	return buffer.Bytes(), nil
+}
 
-		counter := 0
-		for (256+imageBuffer.Len())%32 != 0 {
-			imageBuffer.WriteByte(deadBeef[counter%4])
-			counter++
-		}
+// thumbnailID derives the ID of a thumbnail file from its images, so that it only changes with them.
+func thumbnailID(images [][]byte) uint32 {
+	hash := crc32.NewIEEE()
+	for _, image := range images {
+		hash.Write(image)
 	}
 
-	buffer.Write(imageBuffer.Bytes())
-	binary.BigEndian.PutUint32(buffer.Bytes()[4:8], uint32(buffer.Len()))
+	// An ID of 0 is avoided, as it is the zero value of the header.
+	if id := hash.Sum32(); id != 0 {
+		return id
+	}
 
-	err = os.WriteFile("thumbnail.bin", buffer.Bytes(), 0666)
-	checkError(err)
+	return 1
 }
@@ -0,0 +1,256 @@
+package tpl
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// Encode converts an image to texture data. The image is padded with transparent pixels
+// to a whole number of tiles.
+func Encode(img image.Image, format Format) ([]byte, error) {
+	bounds := img.Bounds()
+	size, err := DataSize(bounds.Dx(), bounds.Dy(), format)
+	if err != nil {
+		return nil, err
+	}
+
+	pixel := func(x, y int) color.NRGBA {
+		point := image.Pt(bounds.Min.X+x, bounds.Min.Y+y)
+		if !point.In(bounds) {
+			return color.NRGBA{}
+		}
+
+		return color.NRGBAModel.Convert(img.At(point.X, point.Y)).(color.NRGBA)
+	}
+
+	data := make([]byte, 0, size)
+	blockWidth, blockHeight, _ := format.blockSize()
+	for blockY := 0; blockY < bounds.Dy(); blockY += blockHeight {
+		for blockX := 0; blockX < bounds.Dx(); blockX += blockWidth {
+			if format == CMPR {
+				// A CMPR tile is four DXT1 blocks, left to right then top to bottom.
+				for _, sub := range []image.Point{{0, 0}, {4, 0}, {0, 4}, {4, 4}} {
+					var block [16]color.NRGBA
+					for i := range block {
+						block[i] = pixel(blockX+sub.X+i%4, blockY+sub.Y+i/4)
+					}
+
+					data = append(data, encodeDXT1(block)...)
+				}
+
+				continue
+			}
+
+			for y := blockY; y < blockY+blockHeight; y++ {
+				for x := blockX; x < blockX+blockWidth; x++ {
+					c := pixel(x, y)
+					switch format {
+					case I8:
+						data = append(data, intensity(c))
+					case RGB565:
+						data = binary.BigEndian.AppendUint16(data, toRGB565(c))
+					case RGB5A3:
+						data = binary.BigEndian.AppendUint16(data, toRGB5A3(c))
+					}
+				}
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// Decode converts texture data to an image.
+func Decode(data []byte, width, height int, format Format) (*image.NRGBA, error) {
+	size, err := DataSize(width, height, format)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < size {
+		return nil, fmt.Errorf("%dx%d %s texture needs 0x%X bytes, got 0x%X", width, height, format, size, len(data))
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	set := func(x, y int, c color.NRGBA) {
+		if x < width && y < height {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	offset := 0
+	blockWidth, blockHeight, _ := format.blockSize()
+	for blockY := 0; blockY < height; blockY += blockHeight {
+		for blockX := 0; blockX < width; blockX += blockWidth {
+			if format == CMPR {
+				for _, sub := range []image.Point{{0, 0}, {4, 0}, {0, 4}, {4, 4}} {
+					block := decodeDXT1(data[offset : offset+8])
+					for i, c := range block {
+						set(blockX+sub.X+i%4, blockY+sub.Y+i/4, c)
+					}
+
+					offset += 8
+				}
+
+				continue
+			}
+
+			for y := blockY; y < blockY+blockHeight; y++ {
+				for x := blockX; x < blockX+blockWidth; x++ {
+					switch format {
+					case I8:
+						set(x, y, color.NRGBA{data[offset], data[offset], data[offset], 0xFF})
+						offset++
+					case RGB565:
+						set(x, y, fromRGB565(binary.BigEndian.Uint16(data[offset:])))
+						offset += 2
+					case RGB5A3:
+						set(x, y, fromRGB5A3(binary.BigEndian.Uint16(data[offset:])))
+						offset += 2
+					}
+				}
+			}
+		}
+	}
+
+	return img, nil
+}
+
+func intensity(c color.NRGBA) uint8 {
+	return uint8((299*uint32(c.R) + 587*uint32(c.G) + 114*uint32(c.B)) / 1000)
+}
+
+func toRGB565(c color.NRGBA) uint16 {
+	return uint16(c.R>>3)<<11 | uint16(c.G>>2)<<5 | uint16(c.B>>3)
+}
+
+func fromRGB565(v uint16) color.NRGBA {
+	r, g, b := uint8(v>>11&0x1F), uint8(v>>5&0x3F), uint8(v&0x1F)
+	return color.NRGBA{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 0xFF}
+}
+
+// toRGB5A3 stores opaque pixels as RGB555 and any other pixel as ARGB3444.
+func toRGB5A3(c color.NRGBA) uint16 {
+	if c.A >= 0xE0 {
+		return 0x8000 | uint16(c.R>>3)<<10 | uint16(c.G>>3)<<5 | uint16(c.B>>3)
+	}
+
+	return uint16(c.A>>5)<<12 | uint16(c.R>>4)<<8 | uint16(c.G>>4)<<4 | uint16(c.B>>4)
+}
+
+func fromRGB5A3(v uint16) color.NRGBA {
+	if v&0x8000 != 0 {
+		r, g, b := uint8(v>>10&0x1F), uint8(v>>5&0x1F), uint8(v&0x1F)
+		return color.NRGBA{r<<3 | r>>2, g<<3 | g>>2, b<<3 | b>>2, 0xFF}
+	}
+
+	a, r, g, b := uint8(v>>12&7), uint8(v>>8&0xF), uint8(v>>4&0xF), uint8(v&0xF)
+	return color.NRGBA{r * 0x11, g * 0x11, b * 0x11, a<<5 | a<<2 | a>>1}
+}
+
+// dxt1Palette returns the four colors a DXT1 block can use.
+// When the first endpoint is not greater than the second, the last color is transparent.
+func dxt1Palette(c0, c1 uint16) [4]color.NRGBA {
+	a, b := fromRGB565(c0), fromRGB565(c1)
+	mix := func(wa, wb, total uint16) color.NRGBA {
+		return color.NRGBA{
+			uint8((wa*uint16(a.R) + wb*uint16(b.R)) / total),
+			uint8((wa*uint16(a.G) + wb*uint16(b.G)) / total),
+			uint8((wa*uint16(a.B) + wb*uint16(b.B)) / total),
+			0xFF,
+		}
+	}
+
+	if c0 > c1 {
+		return [4]color.NRGBA{a, b, mix(2, 1, 3), mix(1, 2, 3)}
+	}
+
+	return [4]color.NRGBA{a, b, mix(1, 1, 2), {}}
+}
+
+func encodeDXT1(block [16]color.NRGBA) []byte {
+	// The endpoints are the corners of the bounding box of the opaque colors.
+	lo := color.NRGBA{0xFF, 0xFF, 0xFF, 0xFF}
+	hi := color.NRGBA{}
+	transparent := false
+	for _, c := range block {
+		if c.A < 0x80 {
+			transparent = true
+			continue
+		}
+
+		lo = color.NRGBA{minByte(lo.R, c.R), minByte(lo.G, c.G), minByte(lo.B, c.B), 0xFF}
+		hi = color.NRGBA{maxByte(hi.R, c.R), maxByte(hi.G, c.G), maxByte(hi.B, c.B), 0xFF}
+	}
+
+	c0, c1 := toRGB565(hi), toRGB565(lo)
+	if transparent != (c0 <= c1) {
+		c0, c1 = c1, c0
+	}
+
+	palette := dxt1Palette(c0, c1)
+	threeColors := c0 <= c1
+	var indices uint32
+	for _, c := range block {
+		index := uint32(3)
+		if c.A >= 0x80 || !transparent {
+			index = nearest(palette, c, threeColors)
+		}
+
+		indices = indices<<2 | index
+	}
+
+	data := binary.BigEndian.AppendUint16(nil, c0)
+	data = binary.BigEndian.AppendUint16(data, c1)
+	return binary.BigEndian.AppendUint32(data, indices)
+}
+
+// nearest returns the index of the palette color closest to c.
+// The last color is not picked from palettes with three colors, as it is transparent.
+func nearest(palette [4]color.NRGBA, c color.NRGBA, threeColors bool) uint32 {
+	colors := len(palette)
+	if threeColors {
+		colors--
+	}
+
+	best, bestDistance := 0, -1
+	for i, p := range palette[:colors] {
+		dr, dg, db := int(p.R)-int(c.R), int(p.G)-int(c.G), int(p.B)-int(c.B)
+		distance := dr*dr + dg*dg + db*db
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+
+	return uint32(best)
+}
+
+func decodeDXT1(data []byte) [16]color.NRGBA {
+	palette := dxt1Palette(binary.BigEndian.Uint16(data), binary.BigEndian.Uint16(data[2:]))
+	indices := binary.BigEndian.Uint32(data[4:])
+
+	var block [16]color.NRGBA
+	for i := range block {
+		block[i] = palette[indices>>(30-2*i)&3]
+	}
+
+	return block
+}
+
+func minByte(a, b uint8) uint8 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxByte(a, b uint8) uint8 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,217 @@
+// Package tpl encodes and decodes Wii textures and the TPL files that hold them.
+package tpl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// Format is the pixel format of a texture.
+type Format uint32
+
+const (
+	I8     Format = 1
+	RGB565 Format = 4
+	RGB5A3 Format = 5
+	CMPR   Format = 14
+)
+
+var formatNames = map[Format]string{
+	I8:     "I8",
+	RGB565: "RGB565",
+	RGB5A3: "RGB5A3",
+	CMPR:   "CMPR",
+}
+
+func (f Format) String() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("Format(%d)", uint32(f))
+}
+
+// ParseFormat returns the Format for a name such as "RGB5A3".
+func ParseFormat(name string) (Format, error) {
+	for format, formatName := range formatNames {
+		if strings.EqualFold(name, formatName) {
+			return format, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown texture format %q", name)
+}
+
+// blockSize returns the width and height of the tiles a format is stored in.
+func (f Format) blockSize() (int, int, error) {
+	switch f {
+	case I8:
+		return 8, 4, nil
+	case RGB565, RGB5A3:
+		return 4, 4, nil
+	case CMPR:
+		return 8, 8, nil
+	}
+
+	return 0, 0, fmt.Errorf("unsupported texture format %s", f)
+}
+
+// DataSize returns the size of the texture data of an image in a format.
+func DataSize(width, height int, format Format) (int, error) {
+	blockWidth, blockHeight, err := format.blockSize()
+	if err != nil {
+		return 0, err
+	}
+
+	width = roundUp(width, blockWidth)
+	height = roundUp(height, blockHeight)
+	switch format {
+	case I8:
+		return width * height, nil
+	case CMPR:
+		return width * height / 2, nil
+	default:
+		return width * height * 2, nil
+	}
+}
+
+func roundUp(n, multiple int) int {
+	return (n + multiple - 1) / multiple * multiple
+}
+
+const (
+	magic = 0x0020AF30
+	// dataOffset is where the texture data of a single image TPL starts, aligned to 32 bytes.
+	dataOffset = 64
+)
+
+type fileHeader struct {
+	Magic            uint32
+	NumberOfImages   uint32
+	ImageTableOffset uint32
+}
+
+type imageTableEntry struct {
+	ImageHeaderOffset   uint32
+	PaletteHeaderOffset uint32
+}
+
+type imageHeader struct {
+	Height     uint16
+	Width      uint16
+	Format     Format
+	DataOffset uint32
+	WrapS      uint32
+	WrapT      uint32
+	MinFilter  uint32
+	MagFilter  uint32
+	LODBias    float32
+	EdgeLOD    uint8
+	MinLOD     uint8
+	MaxLOD     uint8
+	Unpacked   uint8
+}
+
+// Texture is the first image of a TPL file.
+type Texture struct {
+	Width  int
+	Height int
+	Format Format
+	// Data is the texture data, as the Wii reads it from memory.
+	Data []byte
+}
+
+// Image decodes the texture.
+func (t *Texture) Image() (*image.NRGBA, error) {
+	return Decode(t.Data, t.Width, t.Height, t.Format)
+}
+
+// EncodeFile encodes an image to a TPL file holding a single texture.
+func EncodeFile(img image.Image, format Format) ([]byte, error) {
+	data, err := Encode(img, format)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() > 0xFFFF || bounds.Dy() > 0xFFFF {
+		return nil, fmt.Errorf("image is too large for a texture: %dx%d", bounds.Dx(), bounds.Dy())
+	}
+
+	buffer := new(bytes.Buffer)
+	for _, v := range []any{
+		fileHeader{Magic: magic, NumberOfImages: 1, ImageTableOffset: 12},
+		imageTableEntry{ImageHeaderOffset: 20},
+		imageHeader{
+			Height:     uint16(bounds.Dy()),
+			Width:      uint16(bounds.Dx()),
+			Format:     format,
+			DataOffset: dataOffset,
+			MinFilter:  1,
+			MagFilter:  1,
+		},
+	} {
+		err = binary.Write(buffer, binary.BigEndian, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	buffer.Write(make([]byte, dataOffset-buffer.Len()))
+	buffer.Write(data)
+	return buffer.Bytes(), nil
+}
+
+// DecodeFile reads the first texture of a TPL file.
+func DecodeFile(contents []byte) (*Texture, error) {
+	reader := bytes.NewReader(contents)
+
+	var header fileHeader
+	err := binary.Read(reader, binary.BigEndian, &header)
+	if err != nil || header.Magic != magic {
+		return nil, errors.New("not a TPL file")
+	} else if header.NumberOfImages == 0 {
+		return nil, errors.New("TPL file has no images")
+	}
+
+	var entry imageTableEntry
+	err = readAt(contents, header.ImageTableOffset, &entry)
+	if err != nil {
+		return nil, fmt.Errorf("reading image table: %w", err)
+	}
+
+	var imgHeader imageHeader
+	err = readAt(contents, entry.ImageHeaderOffset, &imgHeader)
+	if err != nil {
+		return nil, fmt.Errorf("reading image header: %w", err)
+	}
+
+	size, err := DataSize(int(imgHeader.Width), int(imgHeader.Height), imgHeader.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	end := uint64(imgHeader.DataOffset) + uint64(size)
+	if end > uint64(len(contents)) {
+		return nil, fmt.Errorf("texture data ends at 0x%X, file is 0x%X bytes", end, len(contents))
+	}
+
+	return &Texture{
+		Width:  int(imgHeader.Width),
+		Height: int(imgHeader.Height),
+		Format: imgHeader.Format,
+		Data:   contents[imgHeader.DataOffset:end],
+	}, nil
+}
+
+func readAt(contents []byte, offset uint32, v any) error {
+	if uint64(offset) > uint64(len(contents)) {
+		return errors.New("offset is out of bounds")
+	}
+
+	return binary.Read(bytes.NewReader(contents[offset:]), binary.BigEndian, v)
+}
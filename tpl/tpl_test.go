@@ -0,0 +1,182 @@
+package tpl
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// testImage returns an image whose size is not a multiple of any tile size, with opaque gradients,
+// and translucent pixels on the right if alpha is set.
+func testImage(alpha bool) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 21, 13))
+	for y := 0; y < 13; y++ {
+		for x := 0; x < 21; x++ {
+			c := color.NRGBA{uint8(x * 12), uint8(y * 19), uint8(255 - x*12), 0xFF}
+			if alpha && x >= 16 {
+				c.A = uint8(y * 16)
+			}
+
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
+
+// grayImage returns an image with a different shade of grey in every pixel.
+func grayImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 21, 13))
+	for y := 0; y < 13; y++ {
+		for x := 0; x < 21; x++ {
+			v := uint8(x*11 + y)
+			img.SetNRGBA(x, y, color.NRGBA{v, v, v, 0xFF})
+		}
+	}
+
+	return img
+}
+
+// blockImage returns an image where every 4x4 block has two colors, or is transparent,
+// which DXT1 stores without loss beyond RGB565.
+func blockImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			block := x/4 + y/4*4
+			c := color.NRGBA{uint8(block * 30), 0x80, uint8(255 - block*30), 0xFF}
+			if (x+y)%2 == 0 {
+				c = color.NRGBA{0xF8, 0xFC, 0xF8, 0xFF}
+			}
+
+			if block == 5 && x%4 < 2 {
+				c = color.NRGBA{}
+			}
+
+			img.SetNRGBA(x, y, c)
+		}
+	}
+
+	return img
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		format Format
+		img    *image.NRGBA
+		// tolerance is how far each channel may be from the original, as the format has fewer bits.
+		tolerance      int
+		alphaTolerance int
+	}{
+		{I8, grayImage(), 0, 0},
+		{RGB565, testImage(false), 8, 0},
+		{RGB5A3, testImage(true), 17, 36},
+		{CMPR, blockImage(), 8, 0},
+	} {
+		t.Run(test.format.String(), func(t *testing.T) {
+			data, err := Encode(test.img, test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			size, err := DataSize(test.img.Bounds().Dx(), test.img.Bounds().Dy(), test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(data) != size {
+				t.Fatalf("encoded to 0x%X bytes, DataSize is 0x%X", len(data), size)
+			}
+
+			decoded, err := Decode(data, test.img.Bounds().Dx(), test.img.Bounds().Dy(), test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			compare(t, test.img, decoded, test.tolerance, test.alphaTolerance)
+
+			// Decoded textures hold only colors the format can store, so encoding them again is lossless.
+			again, err := Encode(decoded, test.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(again, data) {
+				t.Error("encoding the decoded texture changed it")
+			}
+		})
+	}
+}
+
+func compare(t *testing.T, want, got *image.NRGBA, tolerance, alphaTolerance int) {
+	t.Helper()
+
+	if want.Bounds() != got.Bounds() {
+		t.Fatalf("decoded to %v, want %v", got.Bounds(), want.Bounds())
+	}
+
+	for y := want.Bounds().Min.Y; y < want.Bounds().Max.Y; y++ {
+		for x := want.Bounds().Min.X; x < want.Bounds().Max.X; x++ {
+			w, g := want.NRGBAAt(x, y), got.NRGBAAt(x, y)
+			if diff(w.A, g.A) > alphaTolerance {
+				t.Fatalf("pixel %d,%d is %v, want %v", x, y, g, w)
+			}
+
+			// The color of fully transparent pixels does not matter.
+			if w.A == 0 {
+				continue
+			}
+
+			if diff(w.R, g.R) > tolerance || diff(w.G, g.G) > tolerance || diff(w.B, g.B) > tolerance {
+				t.Fatalf("pixel %d,%d is %v, want %v", x, y, g, w)
+			}
+		}
+	}
+}
+
+func diff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+
+	return int(b - a)
+}
+
+func TestFileRoundTrip(t *testing.T) {
+	img := testImage(true)
+	contents, err := EncodeFile(img, RGB5A3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	texture, err := DecodeFile(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if texture.Width != 21 || texture.Height != 13 || texture.Format != RGB5A3 {
+		t.Fatalf("decoded a %dx%d %s texture, want 21x13 RGB5A3", texture.Width, texture.Height, texture.Format)
+	}
+
+	data, err := Encode(img, RGB5A3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(texture.Data, data) {
+		t.Error("texture data of the file differs from Encode")
+	}
+}
+
+func TestDecodeFileTruncated(t *testing.T) {
+	contents, err := EncodeFile(testImage(false), RGB565)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = DecodeFile(contents[:len(contents)-1])
+	if err == nil {
+		t.Error("DecodeFile accepted a truncated file")
+	}
+}